@@ -0,0 +1,345 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BoolColumn is a column of booleans.  The values are packed into a Mask,
+// one bit per row, and missing values are tracked in a validity bitmap
+// which is only allocated once the first null is added
+type BoolColumn struct {
+	ColumnName string
+	ColumnType reflect.Kind
+	nullable[bool, Mask, *Mask]
+}
+
+// GetValueAtIndex will fetch the value for this column at the ndx
+// provided.  If the index is out of bounds, it will return an
+// IndexOutOfBounds error
+func (c BoolColumn) GetValueAtIndex(ndx int) (bool, error) {
+	if ndx < 0 || ndx >= c.Length() {
+		return false, IndexOutOfBounds{c.ColumnName, ndx, c.Length()}
+	}
+	return c.data.get(ndx), nil
+}
+
+// GetNullableValueAtIndex will fetch the value for this column at the
+// ndx provided along with a bool that is false if the value is null
+func (c BoolColumn) GetNullableValueAtIndex(ndx int) (bool, bool, error) {
+	val, err := c.GetValueAtIndex(ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, c.IsValid(ndx), nil
+}
+
+// AppendColumn will append every value of the other column, including
+// its nulls, to this column
+func (c *BoolColumn) AppendColumn(other BoolColumn) {
+	c.appendNullable(other.nullable)
+}
+
+// Mask will return a Mask that is true for every value in the column
+// that is true.  Nulls are false, so the result can be handed straight
+// to Dataframe.Where
+func (c BoolColumn) Mask() Mask {
+	if !c.hasValidity() {
+		return c.data.slice(0, c.Length())
+	}
+	mask, _ := c.data.And(c.validity)
+	return mask
+}
+
+// FillNull returns a new column where every null has been replaced
+// with the value provided
+func (c BoolColumn) FillNull(value bool) *BoolColumn {
+	return c.derive(c.fillNull(value))
+}
+
+// FillForward returns a new column where every null has been replaced
+// with the last value before it that was not null.  Nulls at the start
+// of the column have nothing to fill from and remain null
+func (c BoolColumn) FillForward() *BoolColumn {
+	return c.derive(c.fillForward())
+}
+
+// FillBackward returns a new column where every null has been replaced
+// with the next value after it that is not null.  Nulls at the end of
+// the column have nothing to fill from and remain null
+func (c BoolColumn) FillBackward() *BoolColumn {
+	return c.derive(c.fillBackward())
+}
+
+// Slice takes a start and stop parameter and returns a new column of
+// the same name
+func (c BoolColumn) Slice(start, stop int) (*BoolColumn, error) {
+	if start < 0 || stop > c.Length() || start > stop {
+		return nil, IndexOutOfBounds{c.ColumnName, stop, c.Length()}
+	}
+	return c.derive(c.slice(start, stop)), nil
+}
+
+// Take returns a new column of the same name that contains the values
+// at the provided indices, in the order given.  An index of -1 produces
+// a null.  If any other index is out of bounds, it will return an
+// IndexOutOfBounds error
+func (c BoolColumn) Take(indices []int) (*BoolColumn, error) {
+	taken, err := c.take(c.ColumnName, indices)
+	if err != nil {
+		return nil, err
+	}
+	return c.derive(taken), nil
+}
+
+// Coalesce returns a new column where every null has been replaced with
+// the value at the same index in the other column, if that value is not
+// null itself.  Both columns must be the same length
+func (c BoolColumn) Coalesce(other BoolColumn) (*BoolColumn, error) {
+	if c.Length() != other.Length() {
+		return nil, RowCountMismatchError{other.ColumnName, c.Length(), other.Length()}
+	}
+	return c.derive(c.coalesce(other.nullable)), nil
+}
+
+// Compare evaluates the operation against every value in the column and
+// returns a Mask that is true for the values that satisfy it.  Null
+// values never satisfy an operation.  Only Equal and NotEqual, which
+// take a single value, and In, which takes one or more, are supported
+func (c BoolColumn) Compare(operation FilterType, values ...bool) (Mask, error) {
+	var predicate func(bool) bool
+	switch operation {
+	case Equal, NotEqual:
+		if len(values) != 1 {
+			return Mask{}, fmt.Errorf("unable to compare column %s: filter of type %s requires 1 value, but found %d", c.ColumnName, operation, len(values))
+		}
+		want := values[0] == (operation == Equal)
+		predicate = func(v bool) bool { return v == want }
+	case In:
+		if len(values) == 0 {
+			return Mask{}, fmt.Errorf("unable to compare column %s: filter of type %s requires at least 1 value", c.ColumnName, operation)
+		}
+		predicate = func(v bool) bool {
+			for _, value := range values {
+				if v == value {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		return Mask{}, fmt.Errorf("unable to compare column %s: filter of type %s not supported for bool columns", c.ColumnName, operation)
+	}
+	mask := NewMask(c.Length())
+	for ndx := 0; ndx < c.Length(); ndx++ {
+		mask.set(ndx, c.IsValid(ndx) && predicate(c.data.get(ndx)))
+	}
+	return mask, nil
+}
+
+// CompareColumn evaluates the operation row by row between this column
+// and another bool column of the same length.  Rows where either value
+// is null never satisfy the operation.  Only Equal and NotEqual are
+// supported
+func (c BoolColumn) CompareColumn(operation FilterType, other BoolColumn) (Mask, error) {
+	if c.Length() != other.Length() {
+		return Mask{}, RowCountMismatchError{other.ColumnName, c.Length(), other.Length()}
+	}
+	if operation != Equal && operation != NotEqual {
+		return Mask{}, fmt.Errorf("unable to compare column %s to %s: filter of type %s not supported for bool columns", c.ColumnName, other.ColumnName, operation)
+	}
+	mask := NewMask(c.Length())
+	for ndx := 0; ndx < c.Length(); ndx++ {
+		equal := c.data.get(ndx) == other.data.get(ndx)
+		mask.set(ndx, c.IsValid(ndx) && other.IsValid(ndx) && equal == (operation == Equal))
+	}
+	return mask, nil
+}
+
+// Filter will take an operation and values and return a new column
+// with the same name that holds only the values satisfying the
+// operation, in their original order.  See Compare for the supported
+// operations
+func (c BoolColumn) Filter(operation FilterType, values ...bool) (*BoolColumn, error) {
+	mask, err := c.Compare(operation, values...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Take(mask.Indices())
+}
+
+// compareRows orders the values at two indices, with false before true
+// and nulls after every other value
+func (c BoolColumn) compareRows(i, j int) int {
+	if order, ok := c.compareNulls(i, j); ok {
+		return order
+	}
+	iVal, jVal := c.data.get(i), c.data.get(j)
+	switch {
+	case iVal == jVal:
+		return 0
+	case jVal:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// keyAt renders the value at ndx as a string that is equal for equal
+// values, for use when grouping or joining on the column
+func (c BoolColumn) keyAt(ndx int) string {
+	return strconv.FormatBool(c.data.get(ndx))
+}
+
+// derive wraps values taken from this column in a new column with the
+// same name
+func (c BoolColumn) derive(n nullable[bool, Mask, *Mask]) *BoolColumn {
+	return &BoolColumn{ColumnName: c.ColumnName, ColumnType: c.ColumnType, nullable: n}
+}
+
+// NewBoolColumn will create a new bool column from existing data.  Use
+// []bool{} as the data argument to create an empty column
+func NewBoolColumn(colName string, data []bool) (*BoolColumn, error) {
+	return &BoolColumn{
+		ColumnName: colName,
+		ColumnType: reflect.Bool,
+		nullable:   nullable[bool, Mask, *Mask]{data: MaskFromBools(data)},
+	}, nil
+}
+
+// NewNullableBoolColumn will create a new bool column from existing data
+// along with a slice that marks which values are present.  The data and
+// valid slices must be the same length
+func NewNullableBoolColumn(colName string, data []bool, valid []bool) (*BoolColumn, error) {
+	if len(data) != len(valid) {
+		return nil, RowCountMismatchError{colName, len(data), len(valid)}
+	}
+	col, err := NewBoolColumn(colName, data)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(valid, false) {
+		col.validity = MaskFromBools(valid)
+	}
+	return col, nil
+}
+
+// parseBool accepts true/false, 1/0 and yes/no in any case
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "1", "yes":
+		return true, nil
+	case "false", "0", "no":
+		return false, nil
+	default:
+		return false, &strconv.NumError{Func: "ParseBool", Num: value, Err: strconv.ErrSyntax}
+	}
+}
+
+// boolSeries is the Series for BoolColumn
+type boolSeries struct {
+	*BoolColumn
+}
+
+func newBoolSeries(columnName string) (Series, error) {
+	col, err := NewBoolColumn(columnName, []bool{})
+	if err != nil {
+		return nil, err
+	}
+	return boolSeries{col}, nil
+}
+
+func (s boolSeries) Name() string {
+	return s.ColumnName
+}
+
+func (s boolSeries) Type() reflect.Kind {
+	return s.ColumnType
+}
+
+func (s boolSeries) Len() int {
+	return s.Length()
+}
+
+func (s boolSeries) Parse(value string) error {
+	val, err := parseBool(value)
+	if err != nil {
+		return err
+	}
+	s.AppendValue(val)
+	return nil
+}
+
+func (s boolSeries) Format(ndx int) string {
+	return strconv.FormatBool(s.data.get(ndx))
+}
+
+func (s boolSeries) Slice(start, stop int) (Series, error) {
+	col, err := s.BoolColumn.Slice(start, stop)
+	if err != nil {
+		return nil, err
+	}
+	return boolSeries{col}, nil
+}
+
+func (s boolSeries) Take(indices []int) (Series, error) {
+	col, err := s.BoolColumn.Take(indices)
+	if err != nil {
+		return nil, err
+	}
+	return boolSeries{col}, nil
+}
+
+func (s boolSeries) Rename(name string) Series {
+	col := s.derive(s.nullable.clone())
+	col.ColumnName = name
+	return boolSeries{col}
+}
+
+func (s boolSeries) CompareValues(operation FilterType, values ...interface{}) (Mask, error) {
+	converted, err := convertBools(s.ColumnName, values)
+	if err != nil {
+		return Mask{}, err
+	}
+	return s.Compare(operation, converted...)
+}
+
+func (s boolSeries) CompareSeries(operation FilterType, other Series) (Mask, error) {
+	otherSeries, ok := other.(boolSeries)
+	if !ok {
+		return Mask{}, WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	return s.CompareColumn(operation, *otherSeries.BoolColumn)
+}
+
+func (s boolSeries) CompareRows(i, j int) int {
+	return s.compareRows(i, j)
+}
+
+func (s boolSeries) Key(ndx int) string {
+	return s.keyAt(ndx)
+}
+
+func (s boolSeries) AppendSeries(other Series) error {
+	otherSeries, ok := other.(boolSeries)
+	if !ok {
+		return WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	s.AppendColumn(*otherSeries.BoolColumn)
+	return nil
+}
+
+func (s boolSeries) fillNA(value interface{}) (Series, error) {
+	converted, err := convertBools(s.ColumnName, []interface{}{value})
+	if err != nil {
+		return nil, err
+	}
+	return boolSeries{s.FillNull(converted[0])}, nil
+}
+
+func (s boolSeries) display(ndx int) interface{} {
+	return s.data.get(ndx)
+}
@@ -0,0 +1,136 @@
+package dataframe
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBoolColumn(t *testing.T) {
+	col, err := NewNullableBoolColumn("halted", []bool{true, false, false, true, true}, []bool{true, true, false, true, true})
+	if err != nil {
+		t.Fatalf("unable to create bool column: %s", err)
+		return
+	}
+	if col.ColumnType != reflect.Bool || col.Length() != 5 || col.NullCount() != 1 {
+		t.Errorf("unexpected column %s of type %s with %d rows and %d nulls", col.ColumnName, col.ColumnType, col.Length(), col.NullCount())
+	}
+	if got := col.Mask().Indices(); !reflect.DeepEqual(got, []int{0, 3, 4}) {
+		t.Errorf("expected true at [0 3 4], but found %v", got)
+	}
+	mask, err := col.Compare(Equal, false)
+	if err != nil {
+		t.Fatalf("unable to compare bool column: %s", err)
+		return
+	}
+	if got := mask.Indices(); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("expected false only at [1], but found %v", got)
+	}
+	if _, err := col.Compare(Greater, false); err == nil {
+		t.Errorf("expected ordering comparisons to be rejected for bool columns")
+	}
+	sliced, err := col.Slice(1, 4)
+	if err != nil {
+		t.Fatalf("unable to slice bool column: %s", err)
+		return
+	}
+	if !sliced.IsNull(1) || sliced.Length() != 3 {
+		t.Errorf("expected the slice to keep the null at index 1")
+	}
+	filled := col.FillForward()
+	if val, valid, _ := filled.GetNullableValueAtIndex(2); !valid || val {
+		t.Errorf("expected the null to be filled forward with false, but found %t, %t", val, valid)
+	}
+}
+
+func TestBoolColumnInDataframe(t *testing.T) {
+	content := `ticker,is_halted,volume
+AAA,true,10
+BBB,NO,20
+CCC,,30
+DDD,1,40
+EEE,yes,50
+`
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "Ticker", ColumnType: reflect.String},
+		{ColumnName: "Halted", ColumnType: reflect.Bool},
+		{ColumnName: "Volume", ColumnType: reflect.Int},
+	})
+	if err != nil {
+		t.Fatalf("unable to create schema with a bool column: %s", err)
+		return
+	}
+	df, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read bool column: %s", err)
+		return
+	}
+	for ndx, expected := range []bool{true, false, false, true, true} {
+		val, err := df.GetBoolValue("Halted", ndx)
+		if err != nil || val != expected {
+			t.Errorf("expected %t at index %d, but found %t (%v)", expected, ndx, val, err)
+		}
+	}
+	if null, _ := df.IsNull("Halted", 2); !null {
+		t.Errorf("expected an empty cell to be null")
+	}
+	if _, err := df.GetBoolValue("Volume", 0); err == nil {
+		t.Errorf("expected an error when reading an int column as bool")
+	}
+	sliced, err := df.Slice(1, 3)
+	if err != nil {
+		t.Fatalf("unable to slice dataframe with a bool column: %s", err)
+		return
+	}
+	if sliced.Length() != 2 {
+		t.Errorf("expected 2 rows in the slice, but found %d", sliced.Length())
+	}
+	if null, _ := sliced.IsNull("Halted", 1); !null {
+		t.Errorf("expected the slice to keep the null")
+	}
+	halted, err := df.Filter("Halted", Equal, true)
+	if err != nil {
+		t.Fatalf("unable to filter on bool column: %s", err)
+		return
+	}
+	if halted.Length() != 3 {
+		t.Errorf("expected 3 halted rows, but found %d", halted.Length())
+	}
+	sorted, err := df.SortBy([]SortKey{{ColumnName: "Halted"}})
+	if err != nil {
+		t.Fatalf("unable to sort on bool column: %s", err)
+		return
+	}
+	testStringHelper(t, "Ticker", 0, "BBB", sorted)
+	testStringHelper(t, "Ticker", 4, "CCC", sorted)
+	grouped, err := df.GroupBy("Halted")
+	if err != nil {
+		t.Fatalf("unable to group on bool column: %s", err)
+		return
+	}
+	if grouped.Length() != 3 {
+		t.Errorf("expected 3 groups including nulls, but found %d", grouped.Length())
+	}
+	table, err := df.Table(0, 0)
+	if err != nil {
+		t.Fatalf("unable to render table: %s", err)
+		return
+	}
+	if !strings.Contains(table.Render(), "true") {
+		t.Errorf("expected the table to render bool values")
+	}
+	var buffer bytes.Buffer
+	if err := df.WriteCSVTo(&buffer, CSVWriteOptions{}); err != nil {
+		t.Fatalf("unable to write bool column: %s", err)
+		return
+	}
+	inferred, err := ReadCSVInfer(&buffer, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to infer written bool column: %s", err)
+		return
+	}
+	if columnType, _ := inferred.GetColumnType("Halted"); columnType != reflect.Bool {
+		t.Errorf("expected the written column to be inferred as bool, but found %s", columnType)
+	}
+}
@@ -0,0 +1,479 @@
+package dataframe
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync/atomic"
+)
+
+// categoryDictionary maps each distinct value of a categorical column to
+// its code.  Columns sliced or taken from one another share a dictionary,
+// so once it is shared it is copied before a new category is added
+type categoryDictionary struct {
+	categories []string
+	codes      map[string]int
+	shared     atomic.Bool
+}
+
+func newCategoryDictionary() *categoryDictionary {
+	return &categoryDictionary{codes: make(map[string]int)}
+}
+
+// CategoricalColumn is a column of strings that stores each distinct
+// value once in a dictionary and an integer code per row.  Equality
+// filters and grouping work on the codes, so columns that repeat a small
+// number of values, such as tickers, are smaller and faster than a
+// Column[string].  Missing values are tracked in a validity bitmap which
+// is only allocated once the first null is added
+type CategoricalColumn struct {
+	ColumnName string
+	ColumnType reflect.Kind
+	nullable[int, values[int], *values[int]]
+	dictionary *categoryDictionary
+}
+
+// GetValueAtIndex will fetch the value for this column at the ndx
+// provided.  Nulls are returned as an empty string.  If the index is
+// out of bounds, it will return an IndexOutOfBounds error
+func (c CategoricalColumn) GetValueAtIndex(ndx int) (string, error) {
+	code, err := c.GetCodeAtIndex(ndx)
+	if err != nil || code < 0 {
+		return "", err
+	}
+	return c.dictionary.categories[code], nil
+}
+
+// GetNullableValueAtIndex will fetch the value for this column at the
+// ndx provided along with a bool that is false if the value is null
+func (c CategoricalColumn) GetNullableValueAtIndex(ndx int) (string, bool, error) {
+	val, err := c.GetValueAtIndex(ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, c.IsValid(ndx), nil
+}
+
+// GetCodeAtIndex will fetch the code stored for the value at the ndx
+// provided, which is its index in Categories.  Nulls have a code of -1
+func (c CategoricalColumn) GetCodeAtIndex(ndx int) (int, error) {
+	if ndx < 0 || ndx >= c.Length() {
+		return -1, IndexOutOfBounds{c.ColumnName, ndx, c.Length()}
+	}
+	if c.IsNull(ndx) {
+		return -1, nil
+	}
+	return c.get(ndx), nil
+}
+
+// Categories will return the distinct values the codes refer to, in the
+// order they were first added.  Columns sliced, taken or filtered from
+// another keep its categories, so some may not appear in the column
+func (c CategoricalColumn) Categories() []string {
+	return slices.Clone(c.dictionary.categories)
+}
+
+// code returns the code for a value, adding it to the dictionary if it
+// is a new category
+func (c *CategoricalColumn) code(val string) int {
+	if code, ok := c.dictionary.codes[val]; ok {
+		return code
+	}
+	if c.dictionary.shared.Load() {
+		c.dictionary = &categoryDictionary{
+			categories: slices.Clone(c.dictionary.categories),
+			codes:      maps.Clone(c.dictionary.codes),
+		}
+	}
+	code := len(c.dictionary.categories)
+	c.dictionary.categories = append(c.dictionary.categories, val)
+	c.dictionary.codes[val] = code
+	return code
+}
+
+// AppendValue will append the value provided to the column
+func (c *CategoricalColumn) AppendValue(val string) {
+	c.nullable.AppendValue(c.code(val))
+}
+
+// AppendColumn will append every value of the other column, including
+// its nulls, to this column.  Categories of the other column that are
+// new to this one are added to the end of its dictionary
+func (c *CategoricalColumn) AppendColumn(other CategoricalColumn) {
+	c.appendNullable(c.recode(other))
+}
+
+// recode returns the codes and nulls of the other column with each code
+// replaced by the code for the same value in this column, adding any
+// categories this column does not have yet
+func (c *CategoricalColumn) recode(other CategoricalColumn) nullable[int, values[int], *values[int]] {
+	remap := make([]int, len(other.dictionary.categories))
+	for code, category := range other.dictionary.categories {
+		remap[code] = c.code(category)
+	}
+	recoded := other.nullable.clone()
+	for ndx, code := range other.data {
+		if other.IsValid(ndx) {
+			recoded.data[ndx] = remap[code]
+		}
+	}
+	return recoded
+}
+
+// ToStringColumn returns the values as a plain string column with the
+// same name and nulls
+func (c CategoricalColumn) ToStringColumn() *Column[string] {
+	newColumn, _ := NewColumn(c.ColumnName, make([]string, c.Length()))
+	for ndx, code := range c.data {
+		if c.IsValid(ndx) {
+			newColumn.data[ndx] = c.dictionary.categories[code]
+		}
+	}
+	if c.hasValidity() {
+		newColumn.validity = c.ValidMask()
+	}
+	return newColumn
+}
+
+// FillNull returns a new column where every null has been replaced
+// with the value provided
+func (c CategoricalColumn) FillNull(value string) *CategoricalColumn {
+	newColumn := c.derive(nullable[int, values[int], *values[int]]{})
+	newColumn.nullable = c.fillNull(newColumn.code(value))
+	return newColumn
+}
+
+// FillForward returns a new column where every null has been replaced
+// with the last value before it that was not null.  Nulls at the start
+// of the column have nothing to fill from and remain null
+func (c CategoricalColumn) FillForward() *CategoricalColumn {
+	return c.derive(c.fillForward())
+}
+
+// FillBackward returns a new column where every null has been replaced
+// with the next value after it that is not null.  Nulls at the end of
+// the column have nothing to fill from and remain null
+func (c CategoricalColumn) FillBackward() *CategoricalColumn {
+	return c.derive(c.fillBackward())
+}
+
+// Slice takes a start and stop parameter and returns a new column of
+// the same name that shares this column's categories
+func (c CategoricalColumn) Slice(start, stop int) (*CategoricalColumn, error) {
+	if start < 0 || stop > c.Length() || start > stop {
+		return nil, IndexOutOfBounds{c.ColumnName, stop, c.Length()}
+	}
+	return c.derive(c.slice(start, stop)), nil
+}
+
+// Take returns a new column of the same name that contains the values
+// at the provided indices, in the order given.  An index of -1 produces
+// a null.  If any other index is out of bounds, it will return an
+// IndexOutOfBounds error
+func (c CategoricalColumn) Take(indices []int) (*CategoricalColumn, error) {
+	taken, err := c.take(c.ColumnName, indices)
+	if err != nil {
+		return nil, err
+	}
+	return c.derive(taken), nil
+}
+
+// Coalesce returns a new column where every null has been replaced with
+// the value at the same index in the other column, if that value is not
+// null itself.  Both columns must be the same length
+func (c CategoricalColumn) Coalesce(other CategoricalColumn) (*CategoricalColumn, error) {
+	if c.Length() != other.Length() {
+		return nil, RowCountMismatchError{other.ColumnName, c.Length(), other.Length()}
+	}
+	newColumn := c.derive(nullable[int, values[int], *values[int]]{})
+	newColumn.nullable = c.coalesce(newColumn.recode(other))
+	return newColumn, nil
+}
+
+// Compare evaluates the operation against every value in the column and
+// returns a Mask that is true for the values that satisfy it.  The
+// operation is evaluated once per category and each row only looks up
+// the result for its code.  Null values never satisfy an operation.  See
+// Column.Compare for how many values each operation expects
+func (c CategoricalColumn) Compare(operation FilterType, values ...string) (Mask, error) {
+	predicate, err := newPredicate(operation, values)
+	if err != nil {
+		return Mask{}, fmt.Errorf("unable to compare column %s: %w", c.ColumnName, err)
+	}
+	matches := make([]bool, len(c.dictionary.categories))
+	for code, category := range c.dictionary.categories {
+		matches[code] = predicate(category)
+	}
+	mask := NewMask(c.Length())
+	for ndx, code := range c.data {
+		mask.set(ndx, c.IsValid(ndx) && matches[code])
+	}
+	return mask, nil
+}
+
+// CompareColumn evaluates the operation row by row between this column
+// and another categorical column of the same length.  Columns that
+// share categories are compared for equality by code.  Rows where either
+// value is null never satisfy the operation.  Only Equal, NotEqual,
+// Greater, GreaterEq, Lesser and LesserEq are supported
+func (c CategoricalColumn) CompareColumn(operation FilterType, other CategoricalColumn) (Mask, error) {
+	if c.Length() != other.Length() {
+		return Mask{}, RowCountMismatchError{other.ColumnName, c.Length(), other.Length()}
+	}
+	comparison, err := newComparison[string](operation)
+	if err != nil {
+		return Mask{}, fmt.Errorf("unable to compare column %s to %s: %w", c.ColumnName, other.ColumnName, err)
+	}
+	byCode := c.dictionary == other.dictionary && (operation == Equal || operation == NotEqual)
+	mask := NewMask(c.Length())
+	for ndx, code := range c.data {
+		if c.IsNull(ndx) || other.IsNull(ndx) {
+			continue
+		}
+		if byCode {
+			mask.set(ndx, (code == other.data[ndx]) == (operation == Equal))
+			continue
+		}
+		mask.set(ndx, comparison(c.dictionary.categories[code], other.dictionary.categories[other.data[ndx]]))
+	}
+	return mask, nil
+}
+
+// Filter will take an operation and values and return a new column
+// with the same name that holds only the values satisfying the
+// operation, in their original order.  See Compare for the supported
+// operations
+func (c CategoricalColumn) Filter(operation FilterType, values ...string) (*CategoricalColumn, error) {
+	mask, err := c.Compare(operation, values...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Take(mask.Indices())
+}
+
+// compareRows orders the values at two indices by their text, with
+// nulls after every other value
+func (c CategoricalColumn) compareRows(i, j int) int {
+	if result, ok := c.compareNulls(i, j); ok {
+		return result
+	}
+	if c.data[i] == c.data[j] {
+		return 0
+	}
+	return cmp.Compare(c.dictionary.categories[c.data[i]], c.dictionary.categories[c.data[j]])
+}
+
+// keyAt renders the value at ndx as a string that is equal for equal
+// values.  Joins compare keys between dataframes whose codes differ, so
+// the key is the value rather than its code.  Nulls are rendered as an
+// empty string
+func (c CategoricalColumn) keyAt(ndx int) string {
+	if c.IsNull(ndx) {
+		return ""
+	}
+	return c.dictionary.categories[c.data[ndx]]
+}
+
+// groupByCode splits the rows into groups by code, in the order each
+// code first appears, with the nulls in their own group
+func (c CategoricalColumn) groupByCode() [][]int {
+	var groups [][]int
+	groupIndex := make([]int, len(c.dictionary.categories)+1)
+	for ndx := range groupIndex {
+		groupIndex[ndx] = -1
+	}
+	for ndx, code := range c.data {
+		if c.IsNull(ndx) {
+			code = len(c.dictionary.categories)
+		}
+		if groupIndex[code] < 0 {
+			groupIndex[code] = len(groups)
+			groups = append(groups, []int{})
+		}
+		groups[groupIndex[code]] = append(groups[groupIndex[code]], ndx)
+	}
+	return groups
+}
+
+// derive returns a column of the same name holding the codes and nulls
+// provided, sharing this column's dictionary
+func (c CategoricalColumn) derive(n nullable[int, values[int], *values[int]]) *CategoricalColumn {
+	c.dictionary.shared.Store(true)
+	return &CategoricalColumn{ColumnName: c.ColumnName, ColumnType: c.ColumnType, nullable: n, dictionary: c.dictionary}
+}
+
+func (c CategoricalColumn) clone() *CategoricalColumn {
+	return c.derive(c.nullable.clone())
+}
+
+// NewCategoricalColumn will create a new categorical column from
+// existing data.  Use []string{} as the data argument to create an
+// empty column
+func NewCategoricalColumn(colName string, data []string) (*CategoricalColumn, error) {
+	col := &CategoricalColumn{
+		ColumnName: colName,
+		ColumnType: Categorical,
+		dictionary: newCategoryDictionary(),
+	}
+	col.data = make(values[int], 0, len(data))
+	for _, val := range data {
+		col.AppendValue(val)
+	}
+	return col, nil
+}
+
+// CategoricalFromColumn converts a string column to a categorical column
+// with the same name and nulls
+func CategoricalFromColumn(col Column[string]) *CategoricalColumn {
+	newColumn, _ := NewCategoricalColumn(col.ColumnName, []string{})
+	for ndx, val := range col.data {
+		if col.IsNull(ndx) {
+			newColumn.AppendNull()
+		} else {
+			newColumn.AppendValue(val)
+		}
+	}
+	return newColumn
+}
+
+// ToCategorical will return a pointer to a new dataframe where the named
+// string columns have been converted to categorical columns.  Asking for
+// a column of any other type returns a WrongColumnTypeError
+func (d Dataframe) ToCategorical(columns ...string) (*Dataframe, error) {
+	var replaced []Series
+	for _, columnName := range columns {
+		columnType, err := d.GetColumnType(columnName)
+		if err != nil {
+			return nil, err
+		}
+		if columnType != reflect.String {
+			return nil, WrongColumnTypeError{columnName, reflect.String, columnType}
+		}
+		replaced = append(replaced, categoricalSeries{CategoricalFromColumn(*d.stringColumn(columnName))})
+	}
+	return d.replacing(replaced...)
+}
+
+// ToStringColumns will return a pointer to a new dataframe where the
+// named categorical columns have been converted back to plain string
+// columns.  Asking for a column of any other type returns a
+// WrongColumnTypeError
+func (d Dataframe) ToStringColumns(columns ...string) (*Dataframe, error) {
+	var replaced []Series
+	for _, columnName := range columns {
+		columnType, err := d.GetColumnType(columnName)
+		if err != nil {
+			return nil, err
+		}
+		if columnType != Categorical {
+			return nil, WrongColumnTypeError{columnName, Categorical, columnType}
+		}
+		replaced = append(replaced, columnSeries[string]{d.categoricalColumn(columnName).ToStringColumn()})
+	}
+	return d.replacing(replaced...)
+}
+
+// categoricalSeries is the Series for CategoricalColumn
+type categoricalSeries struct {
+	*CategoricalColumn
+}
+
+func newCategoricalSeries(columnName string) (Series, error) {
+	col, err := NewCategoricalColumn(columnName, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return categoricalSeries{col}, nil
+}
+
+func (s categoricalSeries) Name() string {
+	return s.ColumnName
+}
+
+func (s categoricalSeries) Type() reflect.Kind {
+	return s.ColumnType
+}
+
+func (s categoricalSeries) Len() int {
+	return s.Length()
+}
+
+func (s categoricalSeries) Parse(value string) error {
+	s.AppendValue(value)
+	return nil
+}
+
+func (s categoricalSeries) Format(ndx int) string {
+	return s.keyAt(ndx)
+}
+
+func (s categoricalSeries) Slice(start, stop int) (Series, error) {
+	col, err := s.CategoricalColumn.Slice(start, stop)
+	if err != nil {
+		return nil, err
+	}
+	return categoricalSeries{col}, nil
+}
+
+func (s categoricalSeries) Take(indices []int) (Series, error) {
+	col, err := s.CategoricalColumn.Take(indices)
+	if err != nil {
+		return nil, err
+	}
+	return categoricalSeries{col}, nil
+}
+
+// Rename copies the codes and nulls but shares the dictionary, which is
+// copied before either column adds a category
+func (s categoricalSeries) Rename(name string) Series {
+	col := s.clone()
+	col.ColumnName = name
+	return categoricalSeries{col}
+}
+
+func (s categoricalSeries) CompareValues(operation FilterType, values ...interface{}) (Mask, error) {
+	converted, err := convertValues[string](s.ColumnName, values)
+	if err != nil {
+		return Mask{}, err
+	}
+	return s.Compare(operation, converted...)
+}
+
+func (s categoricalSeries) CompareSeries(operation FilterType, other Series) (Mask, error) {
+	otherSeries, ok := other.(categoricalSeries)
+	if !ok {
+		return Mask{}, WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	return s.CompareColumn(operation, *otherSeries.CategoricalColumn)
+}
+
+func (s categoricalSeries) CompareRows(i, j int) int {
+	return s.compareRows(i, j)
+}
+
+func (s categoricalSeries) Key(ndx int) string {
+	return s.keyAt(ndx)
+}
+
+func (s categoricalSeries) AppendSeries(other Series) error {
+	otherSeries, ok := other.(categoricalSeries)
+	if !ok {
+		return WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	s.AppendColumn(*otherSeries.CategoricalColumn)
+	return nil
+}
+
+func (s categoricalSeries) fillNA(value interface{}) (Series, error) {
+	converted, err := convertValues[string](s.ColumnName, []interface{}{value})
+	if err != nil {
+		return nil, err
+	}
+	return categoricalSeries{s.FillNull(converted[0])}, nil
+}
+
+func (s categoricalSeries) display(ndx int) interface{} {
+	return s.keyAt(ndx)
+}
@@ -0,0 +1,191 @@
+package dataframe
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCategoricalColumn(t *testing.T) {
+	col, err := NewCategoricalColumn("ticker", []string{"BBB", "AAA", "BBB", "CCC", "AAA"})
+	if err != nil {
+		t.Fatalf("unable to create categorical column: %s", err)
+		return
+	}
+	col.AppendNull()
+	if got := col.Categories(); !reflect.DeepEqual(got, []string{"BBB", "AAA", "CCC"}) {
+		t.Errorf("expected categories in order of appearance, but found %v", got)
+	}
+	if code, _ := col.GetCodeAtIndex(2); code != 0 {
+		t.Errorf("expected BBB to have code 0, but found %d", code)
+	}
+	if code, _ := col.GetCodeAtIndex(5); code != -1 || !col.IsNull(5) {
+		t.Errorf("expected the null to have code -1, but found %d", code)
+	}
+	mask, err := col.Compare(In, "AAA", "CCC", "ZZZ")
+	if err != nil {
+		t.Fatalf("unable to compare categorical column: %s", err)
+		return
+	}
+	if got := mask.Indices(); !reflect.DeepEqual(got, []int{1, 3, 4}) {
+		t.Errorf("expected AAA and CCC at [1 3 4], but found %v", got)
+	}
+	mask, err = col.Compare(Greater, "AAA")
+	if err != nil {
+		t.Fatalf("unable to compare categorical column: %s", err)
+		return
+	}
+	if got := mask.Indices(); !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Errorf("expected values after AAA at [0 2 3], but found %v", got)
+	}
+
+	sliced, err := col.Slice(0, 2)
+	if err != nil {
+		t.Fatalf("unable to slice categorical column: %s", err)
+		return
+	}
+	sliced.AppendValue("DDD")
+	if len(col.Categories()) != 3 || len(sliced.Categories()) != 4 {
+		t.Errorf("expected a new category in a slice to leave the original alone, but found %v and %v", col.Categories(), sliced.Categories())
+	}
+	plain := col.ToStringColumn()
+	if val, _ := plain.GetValueAtIndex(3); val != "CCC" || !plain.IsNull(5) {
+		t.Errorf("expected the string column to keep values and nulls")
+	}
+	if back := CategoricalFromColumn(*plain); !reflect.DeepEqual(back.data, col.data) {
+		t.Errorf("expected a round trip to keep the codes, but found %v", back.data)
+	}
+}
+
+func TestCategoricalNullsFormatEmpty(t *testing.T) {
+	onlyNulls, _ := NewCategoricalColumn("ticker", []string{})
+	onlyNulls.AppendNull()
+	mixed, _ := NewCategoricalColumn("ticker", []string{"AAA"})
+	mixed.AppendNull()
+	for _, series := range []categoricalSeries{{onlyNulls}, {mixed}} {
+		last := series.Len() - 1
+		if got := series.Format(last); got != "" {
+			t.Errorf("expected a null to format as an empty string, but found %q", got)
+		}
+		if got := series.Key(last); got != "" {
+			t.Errorf("expected a null to have an empty key, but found %q", got)
+		}
+	}
+}
+
+func TestCategoricalColumnInDataframe(t *testing.T) {
+	content := `ticker,volume
+BBB,10
+AAA,20
+BBB,30
+,40
+AAA,50
+`
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "ticker", ColumnType: Categorical},
+		{ColumnName: "volume", ColumnType: reflect.Int},
+	})
+	if err != nil {
+		t.Fatalf("unable to create schema with a categorical column: %s", err)
+		return
+	}
+	df, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true, Workers: 2, BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unable to read categorical column: %s", err)
+		return
+	}
+	for ndx, expected := range []string{"BBB", "AAA", "BBB", "", "AAA"} {
+		val, err := df.GetCategoricalValue("ticker", ndx)
+		if err != nil || val != expected {
+			t.Errorf("expected %q at index %d, but found %q (%v)", expected, ndx, val, err)
+		}
+	}
+	if null, _ := df.IsNull("ticker", 3); !null {
+		t.Errorf("expected an empty cell to be null")
+	}
+	filtered, err := df.Filter("ticker", Equal, "AAA")
+	if err != nil {
+		t.Fatalf("unable to filter categorical column: %s", err)
+		return
+	}
+	testIntHelper(t, "volume", 1, 50, filtered)
+	grouped, err := df.GroupBy("ticker")
+	if err != nil {
+		t.Fatalf("unable to group on categorical column: %s", err)
+		return
+	}
+	sums, err := grouped.Sum()
+	if err != nil {
+		t.Fatalf("unable to sum groups: %s", err)
+		return
+	}
+	if sums.Length() != 3 {
+		t.Errorf("expected 3 groups including nulls, but found %d", sums.Length())
+	}
+	if ticker, _ := sums.GetCategoricalValue("ticker", 0); ticker != "BBB" {
+		t.Errorf("expected BBB to be the first group, but found %s", ticker)
+	}
+	testIntHelper(t, "volume", 0, 40, sums)
+	sorted, err := df.SortBy([]SortKey{{ColumnName: "ticker"}})
+	if err != nil {
+		t.Fatalf("unable to sort on categorical column: %s", err)
+		return
+	}
+	testIntHelper(t, "volume", 0, 20, sorted)
+	testIntHelper(t, "volume", 4, 40, sorted)
+
+	other := New()
+	tickers, _ := NewCategoricalColumn("ticker", []string{"AAA", "CCC"})
+	names, _ := NewColumn[string]("name", []string{"Alpha", "Charlie"})
+	if err := other.AddCategoricalColumn(*tickers); err != nil {
+		t.Fatalf("unable to add categorical column: %s", err)
+		return
+	}
+	if err := other.AddStringColumn(*names); err != nil {
+		t.Fatalf("unable to add string column: %s", err)
+		return
+	}
+	joined, err := Join(df, other, []string{"ticker"}, InnerJoin)
+	if err != nil {
+		t.Fatalf("unable to join on categorical columns with different codes: %s", err)
+		return
+	}
+	if joined.Length() != 2 {
+		t.Errorf("expected 2 joined rows, but found %d", joined.Length())
+	}
+	combined, err := ConcatRelaxed(df, other)
+	if err != nil {
+		t.Fatalf("unable to concatenate categorical columns: %s", err)
+		return
+	}
+	if ticker, _ := combined.GetCategoricalValue("ticker", 6); ticker != "CCC" {
+		t.Errorf("expected CCC at the end of the concatenated column, but found %s", ticker)
+	}
+
+	var buffer bytes.Buffer
+	if err := df.WriteCSVTo(&buffer, CSVWriteOptions{}); err != nil {
+		t.Fatalf("unable to write categorical column: %s", err)
+		return
+	}
+	if buffer.String() != content {
+		t.Errorf("expected the categorical column to be written as text, but found\n%s", buffer.String())
+	}
+	plain, err := df.ToStringColumns("ticker")
+	if err != nil {
+		t.Fatalf("unable to convert to a string column: %s", err)
+		return
+	}
+	testStringHelper(t, "ticker", 1, "AAA", plain)
+	again, err := plain.ToCategorical("ticker")
+	if err != nil {
+		t.Fatalf("unable to convert to a categorical column: %s", err)
+		return
+	}
+	if columnType, _ := again.GetColumnType("ticker"); columnType != Categorical {
+		t.Errorf("expected a categorical column, but found %s", kindName(columnType))
+	}
+	if _, err := df.ToCategorical("volume"); err == nil {
+		t.Errorf("expected converting an int column to be rejected")
+	}
+}
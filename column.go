@@ -1,8 +1,11 @@
 package dataframe
 
 import (
+	"cmp"
 	"fmt"
+	"math"
 	"reflect"
+	"slices"
 )
 
 // FilterType allows for slicing based off of values
@@ -14,6 +17,9 @@ const (
 	GreaterEq FilterType = "GreaterEq"
 	Lesser    FilterType = "Lesser"
 	LesserEq  FilterType = "LesserEq"
+	NotEqual  FilterType = "NotEqual"
+	In        FilterType = "In"
+	Between   FilterType = "Between"
 )
 
 // Columnable represents the allowed column types
@@ -21,20 +27,26 @@ type Columnable interface {
 	string | float64 | int | int64
 }
 
+// Numeric represents the column types that support arithmetic
+type Numeric interface {
+	float64 | int | int64
+}
+
 // Column is a structure that holds data for a dataframe
 // the goal is to leave all slicing for each column
-// up to the column
+// up to the column.  Missing values are tracked in a validity
+// bitmap which is only allocated once the first null is added
 type Column[T Columnable] struct {
 	ColumnName string
 	ColumnType reflect.Kind
-	data       []T
+	nullable[T, values[T], *values[T]]
 }
 
 // GetValueAtIndex will fetch the value for this column
 // at the ndx provided.  If the index is out of bounds, it
 // will return an IndexOutOfBounds error
 func (c Column[T]) GetValueAtIndex(ndx int) (T, error) {
-	if ndx > c.Length()-1 || ndx >= c.Length() {
+	if ndx < 0 || ndx >= c.Length() {
 		return *new(T), IndexOutOfBounds{c.ColumnName, ndx, c.Length()}
 	}
 	return c.data[ndx], nil
@@ -52,35 +64,150 @@ func (c *Column[T]) GetFirstIndexOfValue(value T) (int, bool) {
 	return -1, false
 }
 
-// AppendValue will append the value provided to the column
-func (c *Column[T]) AppendValue(val T) {
-	c.data = append(c.data, val)
+// GetNullableValueAtIndex will fetch the value for this column at
+// the ndx provided along with a bool that is false if the value is
+// null.  If the index is out of bounds, it will return an
+// IndexOutOfBounds error
+func (c Column[T]) GetNullableValueAtIndex(ndx int) (T, bool, error) {
+	val, err := c.GetValueAtIndex(ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, c.IsValid(ndx), nil
+}
+
+// AppendColumn will append every value of the other column, including
+// its nulls, to this column
+func (c *Column[T]) AppendColumn(other Column[T]) {
+	c.appendNullable(other.nullable)
+}
+
+// FillNull returns a new column where every null has been replaced
+// with the value provided
+func (c Column[T]) FillNull(value T) *Column[T] {
+	return c.derive(c.fillNull(value))
+}
+
+// FillForward returns a new column where every null has been replaced
+// with the last value before it that was not null.  Nulls at the start
+// of the column have nothing to fill from and remain null
+func (c Column[T]) FillForward() *Column[T] {
+	return c.derive(c.fillForward())
+}
+
+// FillBackward returns a new column where every null has been replaced
+// with the next value after it that is not null.  Nulls at the end of
+// the column have nothing to fill from and remain null
+func (c Column[T]) FillBackward() *Column[T] {
+	return c.derive(c.fillBackward())
+}
+
+// keyAt renders the value at ndx as a string that is equal for equal
+// values, for use when grouping or joining on the column
+func (c Column[T]) keyAt(ndx int) string {
+	return fmt.Sprint(c.data[ndx])
 }
 
-// Length will return an integer representing the number of entries
-// in this column
-func (c Column[T]) Length() int {
-	return len(c.data)
+func (c Column[T]) clone() *Column[T] {
+	return c.derive(c.nullable.clone())
+}
+
+// derive wraps values taken from this column in a new column with the
+// same name and type
+func (c Column[T]) derive(n nullable[T, values[T], *values[T]]) *Column[T] {
+	return &Column[T]{ColumnName: c.ColumnName, ColumnType: c.ColumnType, nullable: n}
 }
 
 // Slice takes a start and stop parameter and returns a new
 // column of the same type and name or an error
 func (c Column[T]) Slice(start, stop int) (*Column[T], error) {
-	newData := c.data[start:stop]
-	return NewColumn(c.ColumnName, newData)
+	if start < 0 || stop > c.Length() || start > stop {
+		return nil, IndexOutOfBounds{c.ColumnName, stop, c.Length()}
+	}
+	return c.derive(c.slice(start, stop)), nil
 }
 
-// Filter will take an operation and a value.  This works by searching
-// for the first appearance of the value, and the applying the operation
-// and returning a new column with the same name and type.  If it cannot
-// find the value, it will return a nil, error
-func (c Column[T]) Filter(operation FilterType, value T) (*Column[T], error) {
-	ndx, ok := c.GetFirstIndexOfValue(value)
-	if !ok {
-		return nil, fmt.Errorf("unable to find value %v in column", value)
+// Take returns a new column of the same name and type that contains
+// the values at the provided indices, in the order given.  An index of
+// -1 produces a null.  If any other index is out of bounds, it will
+// return an IndexOutOfBounds error
+func (c Column[T]) Take(indices []int) (*Column[T], error) {
+	taken, err := c.take(c.ColumnName, indices)
+	if err != nil {
+		return nil, err
 	}
-	start, stop := getBoundsForFilter(operation, ndx, c.Length())
-	return c.Slice(start, stop)
+	return c.derive(taken), nil
+}
+
+// Coalesce returns a new column where every null has been replaced with
+// the value at the same index in the other column, if that value is not
+// null itself.  Both columns must be the same length
+func (c Column[T]) Coalesce(other Column[T]) (*Column[T], error) {
+	if c.Length() != other.Length() {
+		return nil, RowCountMismatchError{other.ColumnName, c.Length(), other.Length()}
+	}
+	return c.derive(c.coalesce(other.nullable)), nil
+}
+
+// Compare evaluates the operation against every value in the column
+// and returns a Mask that is true for the values that satisfy it.  Null
+// values never satisfy an operation.  Equal,
+// NotEqual, Greater, GreaterEq, Lesser and LesserEq take a single value,
+// In takes one or more values and Between takes an inclusive lower and
+// upper bound
+func (c Column[T]) Compare(operation FilterType, values ...T) (Mask, error) {
+	predicate, err := newPredicate(operation, values)
+	if err != nil {
+		return Mask{}, fmt.Errorf("unable to compare column %s: %w", c.ColumnName, err)
+	}
+	mask := NewMask(c.Length())
+	for ndx, v := range c.data {
+		mask.set(ndx, c.IsValid(ndx) && predicate(v))
+	}
+	return mask, nil
+}
+
+// CompareColumn evaluates the operation row by row between this column
+// and another column of the same type and length, returning a Mask that
+// is true where the value in this column satisfies the operation against
+// the value in the other.  Rows where either value is null never
+// satisfy the operation.  Only Equal, NotEqual, Greater, GreaterEq,
+// Lesser and LesserEq are supported
+func (c Column[T]) CompareColumn(operation FilterType, other Column[T]) (Mask, error) {
+	if c.Length() != other.Length() {
+		return Mask{}, RowCountMismatchError{other.ColumnName, c.Length(), other.Length()}
+	}
+	comparison, err := newComparison[T](operation)
+	if err != nil {
+		return Mask{}, fmt.Errorf("unable to compare column %s to %s: %w", c.ColumnName, other.ColumnName, err)
+	}
+	mask := NewMask(c.Length())
+	for ndx, v := range c.data {
+		mask.set(ndx, c.IsValid(ndx) && other.IsValid(ndx) && comparison(v, other.data[ndx]))
+	}
+	return mask, nil
+}
+
+// Filter will take an operation and values and return a new column
+// with the same name and type that holds only the values satisfying
+// the operation, in their original order.  The data does not need to be
+// sorted.  See Compare for how many values each operation expects
+func (c Column[T]) Filter(operation FilterType, values ...T) (*Column[T], error) {
+	mask, err := c.Compare(operation, values...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Take(mask.Indices())
+}
+
+// compareRows orders the values at two indices, returning a negative
+// number when i sorts first, a positive number when j sorts first and
+// zero when they are equal.  Nulls sort after every other value
+func (c Column[T]) compareRows(i, j int) int {
+	if order, ok := c.compareNulls(i, j); ok {
+		return order
+	}
+	return cmp.Compare(c.data[i], c.data[j])
 }
 
 // NewColumn will create a new column from existing data.  If you don't
@@ -92,6 +219,70 @@ func NewColumn[T Columnable](colName string, data []T) (*Column[T], error) {
 	return &Column[T]{
 		ColumnName: colName,
 		ColumnType: tType.Elem().Kind(),
-		data:       data,
+		nullable:   nullable[T, values[T], *values[T]]{data: data},
 	}, nil
 }
+
+// NewNullableColumn will create a new column from existing data along
+// with a slice that marks which values are present.  Any entry in valid
+// that is false makes the value at that index null.  The data and valid
+// slices must be the same length
+func NewNullableColumn[T Columnable](colName string, data []T, valid []bool) (*Column[T], error) {
+	if len(data) != len(valid) {
+		return nil, RowCountMismatchError{colName, len(data), len(valid)}
+	}
+	col, err := NewColumn(colName, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(valid) > 0 && slices.Contains(valid, false) {
+		col.validity = MaskFromBools(valid)
+	}
+	return col, nil
+}
+
+// InterpolateColumn returns a new numeric column where every null that sits
+// between two values has been replaced by linearly interpolating on
+// the row position.  Integer columns are rounded to the nearest whole
+// number.  Nulls at the start or end of the column remain null
+func InterpolateColumn[T Numeric](c Column[T]) *Column[T] {
+	newColumn := c.clone()
+	if !c.hasValidity() {
+		return newColumn
+	}
+	last := -1
+	for ndx := 0; ndx < c.Length(); ndx++ {
+		if c.IsNull(ndx) {
+			continue
+		}
+		if last >= 0 && ndx-last > 1 {
+			start := float64(c.data[last])
+			step := (float64(c.data[ndx]) - start) / float64(ndx-last)
+			for fill := last + 1; fill < ndx; fill++ {
+				value := start + step*float64(fill-last)
+				if c.ColumnType != reflect.Float64 {
+					value = math.Round(value)
+				}
+				newColumn.data[fill] = T(value)
+				newColumn.validity.set(fill, true)
+			}
+		}
+		last = ndx
+	}
+	return newColumn
+}
+
+// ConvertColumn returns a copy of a numeric column converted to another
+// numeric type, keeping its name and nulls.  Converting to a narrower
+// type follows the usual Go conversion rules
+func ConvertColumn[S Numeric, D Numeric](c Column[S]) *Column[D] {
+	newData := make([]D, 0, c.Length())
+	for _, val := range c.data {
+		newData = append(newData, D(val))
+	}
+	newColumn, _ := NewColumn(c.ColumnName, newData)
+	if c.hasValidity() {
+		newColumn.validity = c.validity.slice(0, c.Length())
+	}
+	return newColumn
+}
@@ -157,24 +157,26 @@ func testSlice[T Columnable](t *testing.T, columnType reflect.Kind, data []T) {
 		t.Errorf("unable to slice column of type %s on value %v: %s", columnType, sliceVar, err)
 		return
 	}
-	if slicedColumn.Length() != 3 {
-		t.Errorf("expected column length to be 3, but got %d", slicedColumn.Length())
+	// Filtering works on values rather than positions, so the expected
+	// result is every value no greater than the one at index 3, in order
+	var expected []T
+	for _, v := range data {
+		if v <= sliceVar {
+			expected = append(expected, v)
+		}
+	}
+	if slicedColumn.Length() != len(expected) {
+		t.Errorf("expected column length to be %d, but got %d", len(expected), slicedColumn.Length())
+		return
 	}
-	for i := 0; i < 3; i++ {
+	for i, expectedVar := range expected {
 		comparisonVar, err := slicedColumn.GetValueAtIndex(i)
 		if err != nil {
 			t.Errorf("unable to retrieve index %d for sliced Column of type %s", i, columnType)
 			continue
 		}
-		if comparisonVar != data[i] {
-			t.Errorf("expected variable %v but found %v", data[i], comparisonVar)
-		}
-		originalVar, err := col.GetValueAtIndex(i)
-		if err != nil {
-			t.Errorf("unable to retrieve index %d for original column of type %s", i, columnType)
-		}
-		if originalVar != comparisonVar {
-			t.Errorf("expected variable %v but found %v when comparing pre and post Sliced columns", originalVar, comparisonVar)
+		if comparisonVar != expectedVar {
+			t.Errorf("expected variable %v but found %v", expectedVar, comparisonVar)
 		}
 	}
 }
@@ -215,3 +217,39 @@ func TestCreateColumnAndAddValues(t *testing.T) {
 		t.Errorf("expected length of 1 for test column, but found %d", col.Length())
 	}
 }
+
+func TestColumnNulls(t *testing.T) {
+	col, err := NewNullableColumn("TestColumn", []int{1, 2, 3}, []bool{true, false, true})
+	if err != nil {
+		t.Fatalf("cannot create nullable column: %s", err)
+		return
+	}
+	col.AppendValue(4)
+	col.AppendNull()
+	if col.Length() != 5 {
+		t.Errorf("expected length of 5 for test column, but found %d", col.Length())
+	}
+	if col.NullCount() != 2 {
+		t.Errorf("expected 2 nulls in test column, but found %d", col.NullCount())
+	}
+	for ndx, expected := range []bool{false, true, false, false, true} {
+		if col.IsNull(ndx) != expected {
+			t.Errorf("expected IsNull to be %t at index %d, but found %t", expected, ndx, col.IsNull(ndx))
+		}
+	}
+	sliced, err := col.Slice(1, 4)
+	if err != nil {
+		t.Fatalf("cannot slice nullable column: %s", err)
+		return
+	}
+	if !sliced.IsNull(0) || sliced.NullCount() != 1 {
+		t.Errorf("expected slicing to keep the null at index 1 of the original column")
+	}
+	_, ok, err := col.GetNullableValueAtIndex(4)
+	if err != nil || ok {
+		t.Errorf("expected index 4 to be null, but found ok=%t err=%v", ok, err)
+	}
+	if _, err := NewNullableColumn("Bad", []int{1}, []bool{}); err == nil {
+		t.Errorf("expected an error when data and validity lengths differ")
+	}
+}
@@ -0,0 +1,148 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	dsnetbzip2 "github.com/dsnet/compress/bzip2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Compression names a compression format for CSV files
+type Compression string
+
+const (
+	// NoCompression leaves the format unset.  Reading detects it from the
+	// leading bytes of the input and writing to a file picks it from the
+	// extension
+	NoCompression Compression = ""
+	// Uncompressed turns detection off, so input is read as plain CSV and
+	// files are written uncompressed whatever their extension
+	Uncompressed Compression = "none"
+	Gzip         Compression = "gzip"
+	Zstd         Compression = "zstd"
+	Bzip2        Compression = "bzip2"
+	Xz           Compression = "xz"
+)
+
+var compressionMagic = []struct {
+	compression Compression
+	magic       []byte
+}{
+	{Gzip, []byte{0x1f, 0x8b}},
+	{Zstd, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{Xz, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+}
+
+// detectCompression returns the format whose magic bytes start header.
+// A bzip2 stream starts with "BZh" and a block size from 1 to 9, which
+// is checked in full so that plain text starting with "BZh" is not
+// mistaken for it
+func detectCompression(header []byte) Compression {
+	for _, entry := range compressionMagic {
+		if bytes.HasPrefix(header, entry.magic) {
+			return entry.compression
+		}
+	}
+	if len(header) >= 4 && bytes.HasPrefix(header, []byte("BZh")) && header[3] >= '1' && header[3] <= '9' {
+		return Bzip2
+	}
+	return NoCompression
+}
+
+// CompressionFromFilename picks a compression format from the extension
+// of a file name: .gz, .zst, .bz2 or .xz.  Any other extension returns
+// NoCompression
+func CompressionFromFilename(filename string) Compression {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".gz", ".gzip":
+		return Gzip
+	case ".zst", ".zstd":
+		return Zstd
+	case ".bz2":
+		return Bzip2
+	case ".xz":
+		return Xz
+	default:
+		return NoCompression
+	}
+}
+
+// NewDecompressReader detects the compression of r from its leading
+// magic bytes and returns a reader of the decompressed data.  Data that
+// is not compressed is returned unchanged.  Close releases the
+// decompressor, but does not close r
+func NewDecompressReader(r io.Reader) (io.ReadCloser, error) {
+	return newDecompressReader(r, NoCompression)
+}
+
+// newDecompressReader works like NewDecompressReader, but only detects
+// the compression when it is NoCompression.  Uncompressed returns r
+// unchanged and any other format is decompressed without checking
+func newDecompressReader(r io.Reader, compression Compression) (io.ReadCloser, error) {
+	if compression == Uncompressed {
+		return io.NopCloser(r), nil
+	}
+	buffered := bufio.NewReader(r)
+	if compression == NoCompression {
+		header, _ := buffered.Peek(6)
+		compression = detectCompression(header)
+	}
+	switch compression {
+	case Gzip:
+		return gzip.NewReader(buffered)
+	case Zstd:
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	case Bzip2:
+		return io.NopCloser(bzip2.NewReader(buffered)), nil
+	case Xz:
+		reader, err := xz.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(reader), nil
+	case NoCompression:
+		return io.NopCloser(buffered), nil
+	default:
+		return nil, fmt.Errorf("compression %s not supported", compression)
+	}
+}
+
+// NewCompressWriter returns a writer that compresses what is written to
+// it in the format given before passing it on to w.  Close must be called
+// to finish the compressed stream, but does not close w
+func NewCompressWriter(w io.Writer, compression Compression) (io.WriteCloser, error) {
+	switch compression {
+	case NoCompression, Uncompressed:
+		return nopWriteCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	case Bzip2:
+		return dsnetbzip2.NewWriter(w, nil)
+	case Xz:
+		return xz.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("compression %s not supported", compression)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,126 @@
+package dataframe
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompressedCSV(t *testing.T) {
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	tempDir, testFileName, err := createTestCSV("compressed.csv")
+	if err != nil {
+		t.Fatalf("unable to generate test data: %s", err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	df, err := FromCSV(testFileName, *schema, true)
+	if err != nil {
+		t.Fatalf("unable to read test data: %s", err)
+		return
+	}
+	extensions := map[Compression]string{Gzip: ".gz", Zstd: ".zst", Bzip2: ".bz2", Xz: ".xz"}
+	for compression, extension := range extensions {
+		filename := path.Join(tempDir, "bars.csv"+extension)
+		if CompressionFromFilename(filename) != compression {
+			t.Errorf("expected %s to be detected as %s", filename, compression)
+		}
+		if err := df.WriteCSV(filename); err != nil {
+			t.Errorf("unable to write %s: %s", filename, err)
+			continue
+		}
+		raw, err := os.ReadFile(filename)
+		if err != nil {
+			t.Errorf("unable to read back %s: %s", filename, err)
+			continue
+		}
+		if bytes.HasPrefix(raw, []byte("Symbol")) {
+			t.Errorf("expected %s to be written compressed", filename)
+		}
+		roundTrip, err := FromCSV(filename, *schema, true)
+		if err != nil {
+			t.Errorf("unable to read %s: %s", filename, err)
+			continue
+		}
+		if roundTrip.Length() != df.Length() {
+			t.Errorf("expected %d rows from %s, but found %d", df.Length(), filename, roundTrip.Length())
+		}
+		testFloatHelper(t, "High", 2, 17.755, roundTrip)
+		inferred, err := FromCSVInfer(filename, CSVOptions{HasHeader: true})
+		if err != nil {
+			t.Errorf("unable to infer %s: %s", filename, err)
+			continue
+		}
+		testBigIntHelper(t, "WindowStart", 2, 16385076000, inferred)
+	}
+
+	// Detection relies on the leading bytes, not the name of the input
+	var buffer bytes.Buffer
+	if err := df.WriteCSVTo(&buffer, CSVWriteOptions{Compression: Zstd}); err != nil {
+		t.Fatalf("unable to write compressed csv to a buffer: %s", err)
+		return
+	}
+	fromBuffer, err := ReadCSVInfer(&buffer, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read compressed csv from a buffer: %s", err)
+		return
+	}
+	if fromBuffer.Length() != df.Length() {
+		t.Errorf("expected %d rows from the buffer, but found %d", df.Length(), fromBuffer.Length())
+	}
+	if _, err := NewCompressWriter(&buffer, Compression("lz4")); err == nil {
+		t.Errorf("expected an error for an unsupported compression")
+	}
+}
+
+func TestCompressionDetection(t *testing.T) {
+	// A plain header starting with the bzip2 signature, but without the
+	// block size that follows it in a real stream
+	content := "BZhost,hits\nsome.example,5\n"
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "BZhost", ColumnType: reflect.String},
+		{ColumnName: "hits", ColumnType: reflect.Int},
+	})
+	if err != nil {
+		t.Fatalf("unable to create schema: %s", err)
+		return
+	}
+	for _, compression := range []Compression{NoCompression, Uncompressed} {
+		df, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true, Compression: compression})
+		if err != nil {
+			t.Errorf("unable to read plain csv starting with BZh using %q: %s", compression, err)
+			continue
+		}
+		testIntHelper(t, "hits", 0, 5, df)
+	}
+	if detectCompression([]byte("BZh9")) != Bzip2 || detectCompression([]byte("BZh0")) != NoCompression {
+		t.Errorf("expected bzip2 to need a block size from 1 to 9")
+	}
+
+	var buffer bytes.Buffer
+	df, _ := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true})
+	if err := df.WriteCSVTo(&buffer, CSVWriteOptions{Compression: Gzip}); err != nil {
+		t.Fatalf("unable to write compressed csv: %s", err)
+		return
+	}
+	compressed := buffer.Bytes()
+	if _, err := ReadCSV(bytes.NewReader(compressed), *schema, CSVOptions{HasHeader: true, Compression: Uncompressed}); err == nil {
+		t.Errorf("expected gzip data read as plain csv to fail")
+	}
+	explicit, err := ReadCSV(bytes.NewReader(compressed), *schema, CSVOptions{HasHeader: true, Compression: Gzip})
+	if err != nil {
+		t.Fatalf("unable to read with an explicit compression: %s", err)
+		return
+	}
+	testIntHelper(t, "hits", 0, 5, explicit)
+	if _, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{Compression: Compression("lz4")}); err == nil {
+		t.Errorf("expected an error for an unsupported compression")
+	}
+}
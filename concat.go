@@ -0,0 +1,167 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Concat stacks the rows of the dataframes provided, in order.  Every
+// dataframe must have the same column names, in the same order, with the
+// same types.  Use ConcatRelaxed to stack dataframes whose columns differ
+func Concat(frames ...*Dataframe) (*Dataframe, error) {
+	if len(frames) == 0 {
+		return New(), nil
+	}
+	first := frames[0]
+	if first == nil {
+		return nil, fmt.Errorf("dataframe 0 is nil")
+	}
+	columnTypes := make(map[string]reflect.Kind)
+	for _, series := range first.columns {
+		columnTypes[series.Name()] = series.Type()
+	}
+	for frameNdx, frame := range frames {
+		if frame == nil {
+			return nil, fmt.Errorf("dataframe %d is nil", frameNdx)
+		}
+		if !slices.Equal(frame.Names(), first.Names()) {
+			return nil, fmt.Errorf("dataframe %d has columns %v, but expected %v", frameNdx, frame.Names(), first.Names())
+		}
+		for _, series := range frame.columns {
+			if series.Type() != columnTypes[series.Name()] {
+				return nil, fmt.Errorf("dataframe %d has an invalid column: %w", frameNdx, WrongColumnTypeError{series.Name(), columnTypes[series.Name()], series.Type()})
+			}
+		}
+	}
+	return concatColumns(frames, first.Names(), columnTypes)
+}
+
+// ConcatRelaxed stacks the rows of the dataframes provided, in order,
+// aligning their columns by name.  The result holds every column that
+// appears in any dataframe, in the order they are first seen, and rows
+// from a dataframe without a column are null in that column.  Numeric
+// columns with different types are promoted from int to int64 to float64
+// as needed, but a string column cannot be combined with a numeric one
+func ConcatRelaxed(frames ...*Dataframe) (*Dataframe, error) {
+	var columnOrder []string
+	columnTypes := make(map[string]reflect.Kind)
+	for frameNdx, frame := range frames {
+		if frame == nil {
+			return nil, fmt.Errorf("dataframe %d is nil", frameNdx)
+		}
+		for _, series := range frame.columns {
+			columnName, frameType := series.Name(), series.Type()
+			currentType, ok := columnTypes[columnName]
+			if !ok {
+				columnOrder = append(columnOrder, columnName)
+				columnTypes[columnName] = frameType
+				continue
+			}
+			promoted, ok := promoteKinds(currentType, frameType)
+			if !ok {
+				return nil, fmt.Errorf("dataframe %d has an invalid column: %w", frameNdx, WrongColumnTypeError{columnName, currentType, frameType})
+			}
+			columnTypes[columnName] = promoted
+		}
+	}
+	return concatColumns(frames, columnOrder, columnTypes)
+}
+
+// promoteKinds finds the type that can hold values of both types, where
+// int widens to int64 and both widen to float64.  Decimal columns only
+// combine with other Decimal columns, so that no value loses precision
+func promoteKinds(a, b reflect.Kind) (reflect.Kind, bool) {
+	if a == b {
+		return a, true
+	}
+	if a == Decimal || b == Decimal || !isNumericKind(a) || !isNumericKind(b) {
+		return reflect.Invalid, false
+	}
+	rank := map[reflect.Kind]int{reflect.Int: 0, reflect.Int64: 1, reflect.Float64: 2}
+	if rank[a] > rank[b] {
+		return a, true
+	}
+	return b, true
+}
+
+func concatColumns(frames []*Dataframe, columnOrder []string, columnTypes map[string]reflect.Kind) (*Dataframe, error) {
+	df := New()
+	for _, columnName := range columnOrder {
+		series, err := emptySeries(frames, columnName, columnTypes[columnName])
+		if err != nil {
+			return nil, err
+		}
+		err = df.AddSeries(series)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, frame := range frames {
+		err := df.appendFrame(*frame)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return df, nil
+}
+
+// emptySeries creates the column that every dataframe's rows are
+// appended to.  It is copied from the first dataframe holding the column
+// with the same type, so time columns keep its format, except that
+// decimal columns take the largest scale so no decimal place is lost
+func emptySeries(frames []*Dataframe, columnName string, columnType reflect.Kind) (Series, error) {
+	var template Series
+	for _, frame := range frames {
+		series := frame.series(columnName)
+		if series == nil || series.Type() != columnType {
+			continue
+		}
+		if template == nil || columnType == Decimal && series.(decimalSeries).Scale > template.(decimalSeries).Scale {
+			template = series
+		}
+	}
+	if template == nil {
+		return newSeries(columnName, columnType)
+	}
+	return template.Take([]int{})
+}
+
+// appendFrame appends the rows of another dataframe to this one.  Columns
+// missing from the other dataframe are filled with nulls, and numeric
+// columns are converted to the type of this dataframe's column
+func (d *Dataframe) appendFrame(other Dataframe) error {
+	for _, series := range d.columns {
+		var err error
+		if other.hasColumn(series.Name()) {
+			err = d.appendColumnFrom(other, series.Name())
+		} else {
+			for ndx := 0; ndx < other.numberRows; ndx++ {
+				series.AppendNull()
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("unable to append column %s: %w", series.Name(), err)
+		}
+	}
+	d.numberRows += other.numberRows
+	return d.IsValid()
+}
+
+func (d *Dataframe) appendColumnFrom(other Dataframe, columnName string) error {
+	target, source := d.series(columnName), other.series(columnName)
+	targetType, sourceType := target.Type(), source.Type()
+	switch {
+	case targetType == sourceType:
+		return appendSeries(target, source)
+	case targetType == reflect.Int64 && sourceType == reflect.Int:
+		d.bigIntColumn(columnName).AppendColumn(*ConvertColumn[int, int64](*other.intColumn(columnName)))
+	case targetType == reflect.Float64 && sourceType == reflect.Int:
+		d.floatColumn(columnName).AppendColumn(*ConvertColumn[int, float64](*other.intColumn(columnName)))
+	case targetType == reflect.Float64 && sourceType == reflect.Int64:
+		d.floatColumn(columnName).AppendColumn(*ConvertColumn[int64, float64](*other.bigIntColumn(columnName)))
+	default:
+		return WrongColumnTypeError{columnName, targetType, sourceType}
+	}
+	return nil
+}
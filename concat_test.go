@@ -0,0 +1,89 @@
+package dataframe
+
+import (
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	df := createTestDataframe(t)
+	first, err := df.Take([]int{0, 1, 2})
+	if err != nil {
+		t.Fatalf("unable to take first rows: %s", err)
+		return
+	}
+	second, err := df.Take([]int{3, 4, 5, 6})
+	if err != nil {
+		t.Fatalf("unable to take second rows: %s", err)
+		return
+	}
+	stacked, err := Concat(first, second)
+	if err != nil {
+		t.Fatalf("unable to concat dataframes: %s", err)
+		return
+	}
+	if stacked.Length() != df.Length() {
+		t.Errorf("expected %d rows, but found %d", df.Length(), stacked.Length())
+	}
+	testIntHelper(t, "Volume", 3, 182507, stacked)
+	testFloatHelper(t, "Close", 6, 17.65, stacked)
+	reordered, err := df.Select("Volume", "Symbol")
+	if err != nil {
+		t.Fatalf("unable to select columns: %s", err)
+		return
+	}
+	if _, err := Concat(df, reordered); err == nil {
+		t.Errorf("expected an error when concatenating dataframes with different columns")
+	}
+}
+
+func TestConcatRelaxed(t *testing.T) {
+	day1 := New()
+	volumes, _ := NewColumn("Volume", []int{1, 2})
+	tickers, _ := NewColumn("Ticker", []string{"AAA", "BBB"})
+	day1.AddIntColumn(*volumes)
+	day1.AddStringColumn(*tickers)
+	day2 := New()
+	bigVolumes, _ := NewColumn("Volume", []int64{3000000000})
+	prices, _ := NewColumn("Price", []float64{1.5})
+	day2.AddBigIntColumn(*bigVolumes)
+	day2.AddFloatColumn(*prices)
+	day3 := New()
+	floatVolumes, _ := NewColumn("Volume", []float64{4.5})
+	day3.AddFloatColumn(*floatVolumes)
+
+	stacked, err := ConcatRelaxed(day1, day2)
+	if err != nil {
+		t.Fatalf("unable to concat dataframes: %s", err)
+		return
+	}
+	if stacked.Length() != 3 {
+		t.Errorf("expected 3 rows, but found %d", stacked.Length())
+	}
+	expectedNames := []string{"Volume", "Ticker", "Price"}
+	for ndx, name := range stacked.Names() {
+		if name != expectedNames[ndx] {
+			t.Errorf("expected column %d to be %s, but found %s", ndx, expectedNames[ndx], name)
+		}
+	}
+	testBigIntHelper(t, "Volume", 0, 1, stacked)
+	testBigIntHelper(t, "Volume", 2, 3000000000, stacked)
+	if isNull, _ := stacked.IsNull("Ticker", 2); !isNull {
+		t.Errorf("expected Ticker to be null for a dataframe without it")
+	}
+	if isNull, _ := stacked.IsNull("Price", 0); !isNull {
+		t.Errorf("expected Price to be null for a dataframe without it")
+	}
+	promoted, err := ConcatRelaxed(day1, day2, day3)
+	if err != nil {
+		t.Fatalf("unable to concat dataframes: %s", err)
+		return
+	}
+	testFloatHelper(t, "Volume", 3, 4.5, promoted)
+	testFloatHelper(t, "Volume", 1, 2, promoted)
+	mismatched := New()
+	badTickers, _ := NewColumn("Ticker", []int{1})
+	mismatched.AddIntColumn(*badTickers)
+	if _, err := ConcatRelaxed(day1, mismatched); err == nil {
+		t.Errorf("expected an error when combining a string column with an int column")
+	}
+}
@@ -2,339 +2,527 @@ package dataframe
 
 import (
 	"fmt"
-	"os"
 	"reflect"
-	"slices"
-	"strconv"
+	"time"
 
 	"github.com/jedib0t/go-pretty/table"
 )
 
 // Dataframe is a structure that stores data in a variety of formats
-// the data is primarily stored as Column types, which contain a type
-// name, and actual data.  The Dataframe must have all columns of the
-// same length
+// the data is stored as an ordered collection of Series, one per
+// column, which may be any of the built in column types or a type added
+// with RegisterType.  The Dataframe must have all columns of the same
+// length
 type Dataframe struct {
-	intColumns    map[string]*Column[int]
-	floatColumns  map[string]*Column[float64]
-	bigIntColumns map[string]*Column[int64]
-	stringColumns map[string]*Column[string]
-	columnTypes   map[string]reflect.Kind
-	columnOrder   []string
-	numberRows    int
+	columns     []Series
+	columnIndex map[string]int
+	numberRows  int
 }
 
 // Slice will return a pointer to a new dataframe that is sliced from
 // the provided start and stop indices using the idiomatic Go slicing
 // indices
 func (d Dataframe) Slice(start, stop int) (*Dataframe, error) {
-	df := Dataframe{}
-	for _, columnName := range d.columnOrder {
-		switch d.columnTypes[columnName] {
-		case reflect.String:
-			column := d.stringColumns[columnName]
-			newColumn, err := column.Slice(start, stop)
-			if err != nil {
-				return nil, fmt.Errorf("unable to slice column %s: %w", columnName, err)
-			}
-			d.AddStringColumn(*newColumn)
-		case reflect.Int:
-			column := d.intColumns[columnName]
-			newColumn, err := column.Slice(start, stop)
-			if err != nil {
-				return nil, fmt.Errorf("unable to slice column %s: %w", columnName, err)
-			}
-			d.AddIntColumn(*newColumn)
-		case reflect.Int64:
-			column := d.bigIntColumns[columnName]
-			newColumn, err := column.Slice(start, stop)
-			if err != nil {
-				return nil, fmt.Errorf("unable to slice column %s: %w", columnName, err)
-			}
-			d.AddBigIntColumn(*newColumn)
-		case reflect.Float64:
-			column := d.floatColumns[columnName]
-			newColumn, err := column.Slice(start, stop)
-			if err != nil {
-				return nil, fmt.Errorf("unable to slice column %s: %w", columnName, err)
-			}
-			d.AddFloatColumn(*newColumn)
-		default:
-			return nil, fmt.Errorf("column %s is an unsupported type", columnName)
+	if start < 0 || stop > d.numberRows || start > stop {
+		return nil, IndexOutOfBounds{"", stop, d.numberRows}
+	}
+	df := New()
+	for _, series := range d.columns {
+		newSeries, err := series.Slice(start, stop)
+		if err == nil {
+			err = df.AddSeries(newSeries)
 		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to slice column %s: %w", series.Name(), err)
+		}
+	}
+	df.numberRows = stop - start
+	return df, nil
+}
+
+// Take will return a pointer to a new dataframe that contains the rows
+// at the provided indices, in the order given.  An index of -1 produces
+// a row of nulls.  If any other index is out of bounds, it will return
+// an IndexOutOfBounds error
+func (d Dataframe) Take(indices []int) (*Dataframe, error) {
+	df := New()
+	for _, series := range d.columns {
+		newSeries, err := series.Take(indices)
+		if err != nil {
+			return nil, fmt.Errorf("unable to take rows from column %s: %w", series.Name(), err)
+		}
+		err = df.AddSeries(newSeries)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return df, nil
+}
+
+// Filter evaluates the operation on every row of the named column and
+// returns a pointer to a new dataframe containing only the rows that
+// match.  It is shorthand for calling Compare and handing the result
+// to Where
+func (d Dataframe) Filter(columnName string, operation FilterType, values ...interface{}) (*Dataframe, error) {
+	mask, err := d.Compare(columnName, operation, values...)
+	if err != nil {
+		return nil, err
+	}
+	return d.Where(mask)
+}
+
+// Where will return a pointer to a new dataframe containing only the
+// rows where the mask is true.  The mask must have one entry per row
+func (d Dataframe) Where(mask Mask) (*Dataframe, error) {
+	if mask.Len() != d.numberRows {
+		return nil, MaskLengthMismatchError{d.numberRows, mask.Len()}
 	}
-	return &df, nil
+	return d.Take(mask.Indices())
+}
+
+// Compare evaluates the operation on every row of the named column and
+// returns a Mask that is true for the rows that match.  The values are
+// converted to the type of the column, so any numeric value can be used
+// against a numeric column as long as no precision is lost.  See
+// Column.Compare for how many values each operation expects.  Columns
+// of a registered type must implement ValueComparer
+func (d Dataframe) Compare(columnName string, operation FilterType, values ...interface{}) (Mask, error) {
+	series, err := d.GetSeries(columnName)
+	if err != nil {
+		return Mask{}, err
+	}
+	comparer, ok := series.(ValueComparer)
+	if !ok {
+		return Mask{}, UnsupportedType{series.Type()}
+	}
+	return comparer.CompareValues(operation, values...)
+}
+
+// CompareColumns evaluates the operation row by row between two columns
+// of the same type, returning a Mask that is true where the left column
+// satisfies the operation against the right column.  For instance,
+// CompareColumns("Close", Greater, "Open") finds the rows that closed up.
+// Columns of a registered type must implement SeriesComparer
+func (d Dataframe) CompareColumns(leftColumn string, operation FilterType, rightColumn string) (Mask, error) {
+	left, err := d.GetSeries(leftColumn)
+	if err != nil {
+		return Mask{}, err
+	}
+	right, err := d.GetSeries(rightColumn)
+	if err != nil {
+		return Mask{}, err
+	}
+	if left.Type() != right.Type() {
+		return Mask{}, WrongColumnTypeError{rightColumn, left.Type(), right.Type()}
+	}
+	comparer, ok := left.(SeriesComparer)
+	if !ok {
+		return Mask{}, UnsupportedType{left.Type()}
+	}
+	return comparer.CompareSeries(operation, right)
 }
 
 // GetIntValue is a method that will fetch the integer value from
 // a specific column and a specific ndx
 func (d Dataframe) GetIntValue(columnName string, ndx int) (int, error) {
-	if !slices.Contains(d.columnOrder, columnName) {
+	if !d.hasColumn(columnName) {
 		return -1, MissingColumnError{columnName, reflect.Int}
 	}
 	if ndx < 0 || ndx > d.numberRows-1 {
 		return -1, IndexOutOfBounds{columnName, ndx, d.numberRows}
 	}
-	if d.columnTypes[columnName] != reflect.Int {
-		return -1, WrongColumnTypeError{columnName, reflect.Int, d.columnTypes[columnName]}
+	if d.columnType(columnName) != reflect.Int {
+		return -1, WrongColumnTypeError{columnName, reflect.Int, d.columnType(columnName)}
 	}
-	column := d.intColumns[columnName]
+	column := d.intColumn(columnName)
 	return column.GetValueAtIndex(ndx)
 }
 
 // GetBigIntValue is a method that will fetch the integer value from
 // a specific column and a specific index
 func (d Dataframe) GetBigIntValue(columnName string, ndx int) (int64, error) {
-	if !slices.Contains(d.columnOrder, columnName) {
+	if !d.hasColumn(columnName) {
 		return -1, MissingColumnError{columnName, reflect.Int64}
 	}
 	if ndx < 0 || ndx > d.numberRows-1 {
 		return -1, IndexOutOfBounds{columnName, ndx, d.numberRows}
 	}
-	if d.columnTypes[columnName] != reflect.Int64 {
-		return -1, WrongColumnTypeError{columnName, reflect.Int64, d.columnTypes[columnName]}
+	if d.columnType(columnName) != reflect.Int64 {
+		return -1, WrongColumnTypeError{columnName, reflect.Int64, d.columnType(columnName)}
 	}
-	column := d.bigIntColumns[columnName]
+	column := d.bigIntColumn(columnName)
 	return column.GetValueAtIndex(ndx)
 }
 
 // GetStringValue is a method that will fetch the integer value from
 // a specific column and a specific ndx
 func (d Dataframe) GetStringValue(columnName string, ndx int) (string, error) {
-	if !slices.Contains(d.columnOrder, columnName) {
+	if !d.hasColumn(columnName) {
 		return "", MissingColumnError{columnName, reflect.String}
 	}
 	if ndx < 0 || ndx > d.numberRows-1 {
 		return "", IndexOutOfBounds{columnName, ndx, d.numberRows}
 	}
-	if d.columnTypes[columnName] != reflect.String {
-		return "", WrongColumnTypeError{columnName, reflect.String, d.columnTypes[columnName]}
+	if d.columnType(columnName) != reflect.String {
+		return "", WrongColumnTypeError{columnName, reflect.String, d.columnType(columnName)}
 	}
-	column := d.stringColumns[columnName]
+	column := d.stringColumn(columnName)
 	return column.GetValueAtIndex(ndx)
 }
 
 // GetFloatValue is a method that will fetch the integer value from
 // a specific column and a specific ndx
 func (d Dataframe) GetFloatValue(columnName string, ndx int) (float64, error) {
-	if !slices.Contains(d.columnOrder, columnName) {
+	if !d.hasColumn(columnName) {
 		return -1, MissingColumnError{columnName, reflect.Float64}
 	}
 	if ndx < 0 || ndx > d.numberRows-1 {
 		return -1, IndexOutOfBounds{columnName, ndx, d.numberRows}
 	}
-	if d.columnTypes[columnName] != reflect.Float64 {
-		return -1, WrongColumnTypeError{columnName, reflect.Float64, d.columnTypes[columnName]}
+	if d.columnType(columnName) != reflect.Float64 {
+		return -1, WrongColumnTypeError{columnName, reflect.Float64, d.columnType(columnName)}
 	}
-	column := d.floatColumns[columnName]
+	column := d.floatColumn(columnName)
 	return column.GetValueAtIndex(ndx)
 }
 
-// AddIntColumn will add a column of type int to the dataframe
-// and check validity
-func (d *Dataframe) AddIntColumn(col Column[int]) error {
-	if d.numberRows == 0 {
-		d.numberRows = col.Length()
+// GetBoolValue is a method that will fetch the bool value from
+// a specific column and a specific ndx
+func (d Dataframe) GetBoolValue(columnName string, ndx int) (bool, error) {
+	if !d.hasColumn(columnName) {
+		return false, MissingColumnError{columnName, reflect.Bool}
 	}
-	if col.Length() != d.numberRows {
-		return RowCountMismatchError{col.ColumnName, d.numberRows, col.Length()}
+	if ndx < 0 || ndx > d.numberRows-1 {
+		return false, IndexOutOfBounds{columnName, ndx, d.numberRows}
 	}
-	if slices.Contains(d.columnOrder, col.ColumnName) {
-		return ColumnAlreadyExists{col.ColumnName}
+	if d.columnType(columnName) != reflect.Bool {
+		return false, WrongColumnTypeError{columnName, reflect.Bool, d.columnType(columnName)}
 	}
-	d.columnOrder = append(d.columnOrder, col.ColumnName)
-	d.intColumns[col.ColumnName] = &col
-	d.columnTypes[col.ColumnName] = col.ColumnType
-	return d.IsValid()
+	column := d.boolColumn(columnName)
+	return column.GetValueAtIndex(ndx)
 }
 
-// AddBigIntColumn will add a column of type int to the dataframe
-// and check validity
-func (d *Dataframe) AddBigIntColumn(col Column[int64]) error {
-	if d.numberRows == 0 {
-		d.numberRows = col.Length()
+// GetTimeValue is a method that will fetch the time from a specific
+// Timestamp or Date column and a specific ndx
+func (d Dataframe) GetTimeValue(columnName string, ndx int) (time.Time, error) {
+	if !d.hasColumn(columnName) {
+		return time.Time{}, MissingColumnError{columnName, Timestamp}
 	}
-	if col.Length() != d.numberRows {
-		return RowCountMismatchError{col.ColumnName, d.numberRows, col.Length()}
+	if ndx < 0 || ndx > d.numberRows-1 {
+		return time.Time{}, IndexOutOfBounds{columnName, ndx, d.numberRows}
 	}
-	if slices.Contains(d.columnOrder, col.ColumnName) {
-		return ColumnAlreadyExists{col.ColumnName}
+	if !isTimeKind(d.columnType(columnName)) {
+		return time.Time{}, WrongColumnTypeError{columnName, Timestamp, d.columnType(columnName)}
 	}
-	d.columnOrder = append(d.columnOrder, col.ColumnName)
-	d.bigIntColumns[col.ColumnName] = &col
-	d.columnTypes[col.ColumnName] = col.ColumnType
-	return d.IsValid()
+	column := d.timeColumn(columnName)
+	return column.GetTimeAtIndex(ndx)
 }
 
-// AddStringColumn will add a column of type int to the dataframe
-// and check validity
-func (d *Dataframe) AddStringColumn(col Column[string]) error {
-	if d.numberRows == 0 {
-		d.numberRows = col.Length()
+// GetDecimalValue is a method that will fetch the exact value from
+// a specific Decimal column and a specific ndx
+func (d Dataframe) GetDecimalValue(columnName string, ndx int) (DecimalValue, error) {
+	if !d.hasColumn(columnName) {
+		return DecimalValue{}, MissingColumnError{columnName, Decimal}
+	}
+	if ndx < 0 || ndx > d.numberRows-1 {
+		return DecimalValue{}, IndexOutOfBounds{columnName, ndx, d.numberRows}
 	}
-	if col.Length() != d.numberRows {
-		return RowCountMismatchError{col.ColumnName, d.numberRows, col.Length()}
+	if d.columnType(columnName) != Decimal {
+		return DecimalValue{}, WrongColumnTypeError{columnName, Decimal, d.columnType(columnName)}
 	}
-	if slices.Contains(d.columnOrder, col.ColumnName) {
-		return ColumnAlreadyExists{col.ColumnName}
+	column := d.decimalColumn(columnName)
+	return column.GetDecimalAtIndex(ndx)
+}
+
+// GetCategoricalValue is a method that will fetch the string value from
+// a specific Categorical column and a specific ndx
+func (d Dataframe) GetCategoricalValue(columnName string, ndx int) (string, error) {
+	if !d.hasColumn(columnName) {
+		return "", MissingColumnError{columnName, Categorical}
 	}
-	d.columnOrder = append(d.columnOrder, col.ColumnName)
-	d.stringColumns[col.ColumnName] = &col
-	d.columnTypes[col.ColumnName] = col.ColumnType
-	return d.IsValid()
+	if ndx < 0 || ndx > d.numberRows-1 {
+		return "", IndexOutOfBounds{columnName, ndx, d.numberRows}
+	}
+	if d.columnType(columnName) != Categorical {
+		return "", WrongColumnTypeError{columnName, Categorical, d.columnType(columnName)}
+	}
+	column := d.categoricalColumn(columnName)
+	return column.GetValueAtIndex(ndx)
+}
+
+// GetNullableIntValue works like GetIntValue, but also returns a bool
+// that is false when the value is null
+func (d Dataframe) GetNullableIntValue(columnName string, ndx int) (int, bool, error) {
+	val, err := d.GetIntValue(columnName, ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, d.intColumn(columnName).IsValid(ndx), nil
+}
+
+// GetNullableBigIntValue works like GetBigIntValue, but also returns
+// a bool that is false when the value is null
+func (d Dataframe) GetNullableBigIntValue(columnName string, ndx int) (int64, bool, error) {
+	val, err := d.GetBigIntValue(columnName, ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, d.bigIntColumn(columnName).IsValid(ndx), nil
+}
+
+// GetNullableStringValue works like GetStringValue, but also returns
+// a bool that is false when the value is null
+func (d Dataframe) GetNullableStringValue(columnName string, ndx int) (string, bool, error) {
+	val, err := d.GetStringValue(columnName, ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, d.stringColumn(columnName).IsValid(ndx), nil
+}
+
+// GetNullableFloatValue works like GetFloatValue, but also returns a
+// bool that is false when the value is null
+func (d Dataframe) GetNullableFloatValue(columnName string, ndx int) (float64, bool, error) {
+	val, err := d.GetFloatValue(columnName, ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, d.floatColumn(columnName).IsValid(ndx), nil
+}
+
+// GetNullableBoolValue works like GetBoolValue, but also returns a
+// bool that is false when the value is null
+func (d Dataframe) GetNullableBoolValue(columnName string, ndx int) (bool, bool, error) {
+	val, err := d.GetBoolValue(columnName, ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, d.boolColumn(columnName).IsValid(ndx), nil
+}
+
+// GetNullableTimeValue works like GetTimeValue, but also returns a
+// bool that is false when the value is null
+func (d Dataframe) GetNullableTimeValue(columnName string, ndx int) (time.Time, bool, error) {
+	val, err := d.GetTimeValue(columnName, ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, d.timeColumn(columnName).IsValid(ndx), nil
+}
+
+// GetNullableDecimalValue works like GetDecimalValue, but also returns
+// a bool that is false when the value is null
+func (d Dataframe) GetNullableDecimalValue(columnName string, ndx int) (DecimalValue, bool, error) {
+	val, err := d.GetDecimalValue(columnName, ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, d.decimalColumn(columnName).IsValid(ndx), nil
+}
+
+// GetNullableCategoricalValue works like GetCategoricalValue, but also
+// returns a bool that is false when the value is null
+func (d Dataframe) GetNullableCategoricalValue(columnName string, ndx int) (string, bool, error) {
+	val, err := d.GetCategoricalValue(columnName, ndx)
+	if err != nil {
+		return val, false, err
+	}
+	return val, d.categoricalColumn(columnName).IsValid(ndx), nil
+}
+
+// IsNull will return true if the value in the named column at ndx is
+// missing.  It returns an error if the column does not exist or the
+// index is out of bounds
+func (d Dataframe) IsNull(columnName string, ndx int) (bool, error) {
+	if _, err := d.GetColumnType(columnName); err != nil {
+		return false, err
+	}
+	if ndx < 0 || ndx > d.numberRows-1 {
+		return false, IndexOutOfBounds{columnName, ndx, d.numberRows}
+	}
+	return d.isNullAt(columnName, ndx), nil
+}
+
+func (d Dataframe) isNullAt(columnName string, ndx int) bool {
+	return d.series(columnName).IsNull(ndx)
+}
+
+// AddIntColumn will add a column of type int to the dataframe
+// and check validity
+func (d *Dataframe) AddIntColumn(col Column[int]) error {
+	return d.AddSeries(columnSeries[int]{&col})
+}
+
+// AddBigIntColumn will add a column of type int to the dataframe
+// and check validity
+func (d *Dataframe) AddBigIntColumn(col Column[int64]) error {
+	return d.AddSeries(columnSeries[int64]{&col})
+}
+
+// AddStringColumn will add a column of type int to the dataframe
+// and check validity
+func (d *Dataframe) AddStringColumn(col Column[string]) error {
+	return d.AddSeries(columnSeries[string]{&col})
 }
 
 // AddStringColumn will add a column of type int to the dataframe
 // and check validity
 func (d *Dataframe) AddFloatColumn(col Column[float64]) error {
+	return d.AddSeries(columnSeries[float64]{&col})
+}
+
+// AddBoolColumn will add a column of type bool to the dataframe
+// and check validity
+func (d *Dataframe) AddBoolColumn(col BoolColumn) error {
+	return d.AddSeries(boolSeries{&col})
+}
+
+// AddTimeColumn will add a Timestamp or Date column to the dataframe
+// and check validity
+func (d *Dataframe) AddTimeColumn(col TimeColumn) error {
+	if !isTimeKind(col.ColumnType) {
+		return UnsupportedType{col.ColumnType}
+	}
+	return d.AddSeries(timeSeries{&col})
+}
+
+// AddDecimalColumn will add a Decimal column to the dataframe and check
+// validity
+func (d *Dataframe) AddDecimalColumn(col DecimalColumn) error {
+	if col.ColumnType != Decimal {
+		return UnsupportedType{col.ColumnType}
+	}
+	return d.AddSeries(decimalSeries{&col})
+}
+
+// AddCategoricalColumn will add a Categorical column to the dataframe
+// and check validity
+func (d *Dataframe) AddCategoricalColumn(col CategoricalColumn) error {
+	if col.ColumnType != Categorical {
+		return UnsupportedType{col.ColumnType}
+	}
+	return d.AddSeries(categoricalSeries{&col})
+}
+
+// AddSeries will add a column of any type, including the types added
+// with RegisterType, to the dataframe and check validity.  A series whose
+// Type is not registered returns an UnsupportedType error, and only the
+// columns of this package may use the built in types
+func (d *Dataframe) AddSeries(series Series) error {
+	if err := checkSeriesType(series); err != nil {
+		return err
+	}
 	if d.numberRows == 0 {
-		d.numberRows = col.Length()
+		d.numberRows = series.Len()
 	}
-	if col.Length() != d.numberRows {
-		return RowCountMismatchError{col.ColumnName, d.numberRows, col.Length()}
+	if series.Len() != d.numberRows {
+		return RowCountMismatchError{series.Name(), d.numberRows, series.Len()}
 	}
-	if slices.Contains(d.columnOrder, col.ColumnName) {
-		return ColumnAlreadyExists{col.ColumnName}
+	if d.hasColumn(series.Name()) {
+		return ColumnAlreadyExists{series.Name()}
 	}
-	d.columnOrder = append(d.columnOrder, col.ColumnName)
-	d.floatColumns[col.ColumnName] = &col
-	d.columnTypes[col.ColumnName] = col.ColumnType
+	d.columnIndex[series.Name()] = len(d.columns)
+	d.columns = append(d.columns, series)
 	return d.IsValid()
 }
 
 // IsValid determines if all columns are the same length, returning
 // an error if they are not all the same length
 func (d *Dataframe) IsValid() error {
-	for _, col := range d.intColumns {
-		if d.numberRows == 0 {
-			d.numberRows = col.Length()
-		}
-		if col.Length() != d.numberRows {
-			return RowCountMismatchError{col.ColumnName, d.numberRows, col.Length()}
-		}
-	}
-	for _, col := range d.floatColumns {
+	for _, series := range d.columns {
 		if d.numberRows == 0 {
-			d.numberRows = col.Length()
+			d.numberRows = series.Len()
 		}
-		if col.Length() != d.numberRows {
-			return RowCountMismatchError{col.ColumnName, d.numberRows, col.Length()}
-		}
-	}
-	for _, col := range d.bigIntColumns {
-		if d.numberRows == 0 {
-			d.numberRows = col.Length()
-		}
-		if col.Length() != d.numberRows {
-			return RowCountMismatchError{col.ColumnName, d.numberRows, col.Length()}
-		}
-	}
-	for _, col := range d.stringColumns {
-		if d.numberRows == 0 {
-			d.numberRows = col.Length()
-		}
-		if col.Length() != d.numberRows {
-			return RowCountMismatchError{col.ColumnName, d.numberRows, col.Length()}
+		if series.Len() != d.numberRows {
+			return RowCountMismatchError{series.Name(), d.numberRows, series.Len()}
 		}
 	}
 	return nil
 }
 
 // ParseValue takes a columnName and a string value and appends it
-// to that column.  This will return an error if the string cannot
-// be converted
+// to that column.  This will return a ParseError if the string cannot
+// be converted.  Bool columns accept true/false, 1/0 and yes/no in any
+// case, time columns use the TimeFormat of the column, and decimal
+// columns reject values with more decimal places than their scale
 func (d *Dataframe) ParseValue(columnName, value string) error {
-	colType, ok := d.columnTypes[columnName]
-	if !ok {
-		return fmt.Errorf("column %s does not exist", columnName)
-	}
-	switch colType {
-	case reflect.String:
-		col := d.stringColumns[columnName]
-		col.AppendValue(value)
-	case reflect.Int:
-		col := d.intColumns[columnName]
-		val, err := strconv.Atoi(value)
-		if err != nil {
-			return fmt.Errorf("unable to parse %s into Int", value)
+	series := d.series(columnName)
+	if series == nil {
+		return MissingColumnError{ColumnName: columnName}
+	}
+	if err := series.Parse(value); err != nil {
+		return ParseError{-1, columnName, value, series.Type(), err}
+	}
+	return nil
+}
+
+// AppendNull takes a columnName and appends a missing value to that
+// column.  This will return an error if the column does not exist
+func (d *Dataframe) AppendNull(columnName string) error {
+	series := d.series(columnName)
+	if series == nil {
+		return MissingColumnError{ColumnName: columnName}
+	}
+	series.AppendNull()
+	return nil
+}
+
+// dropPartialRow truncates every column to the length of the shortest
+// one, undoing the values appended for a row that failed part way through
+func (d *Dataframe) dropPartialRow() {
+	length := -1
+	for _, series := range d.columns {
+		if length < 0 || series.Len() < length {
+			length = series.Len()
 		}
-		col.AppendValue(val)
-	case reflect.Int64:
-		col := d.bigIntColumns[columnName]
-		val, err := strconv.ParseInt(value, 10, 64)
-		if err != nil {
-			return fmt.Errorf("unable to parse %s into Int64", value)
+	}
+	for ndx, series := range d.columns {
+		if series.Len() == length {
+			continue
 		}
-		col.AppendValue(val)
-	case reflect.Float64:
-		col := d.floatColumns[columnName]
-		val, err := strconv.ParseFloat(value, 64)
-		if err != nil {
-			return fmt.Errorf("unable to parse %s into Float64", value)
+		if t, ok := series.(truncater); ok {
+			t.truncate(length)
+			continue
+		}
+		if sliced, err := series.Slice(0, length); err == nil {
+			d.columns[ndx] = sliced
 		}
-		col.AppendValue(val)
 	}
-	return nil
 }
 
 // New is the dataframe constructor, as there are complex data types
 // that need to be initialized for use
 func New() *Dataframe {
 	return &Dataframe{
-		numberRows:    0,
-		intColumns:    make(map[string]*Column[int]),
-		stringColumns: make(map[string]*Column[string]),
-		bigIntColumns: make(map[string]*Column[int64]),
-		floatColumns:  make(map[string]*Column[float64]),
-		columnTypes:   make(map[string]reflect.Kind),
-		columnOrder:   []string{},
+		numberRows:  0,
+		columns:     []Series{},
+		columnIndex: make(map[string]int),
 	}
 }
 
 func (d Dataframe) createHeader(columnCount int) []interface{} {
 	var row []interface{}
-	for _, columnName := range d.columnOrder[:columnCount] {
-		row = append(row, columnName)
+	for _, series := range d.columns[:columnCount] {
+		row = append(row, series.Name())
 	}
 	return row
 }
 
 func (d Dataframe) createRowFromNdx(ndx, columnCount int) ([]interface{}, error) {
+	if ndx < 0 || ndx > d.numberRows-1 {
+		return nil, IndexOutOfBounds{"", ndx, d.numberRows}
+	}
 	var row []interface{}
-	for _, columnName := range d.columnOrder[:columnCount] {
-		colType := d.columnTypes[columnName]
-		switch colType {
-		case reflect.String:
-			col := d.stringColumns[columnName]
-			val, err := col.GetValueAtIndex(ndx)
-			if err != nil {
-				return nil, err
-			}
-			row = append(row, val)
-		case reflect.Int:
-			col := d.intColumns[columnName]
-			val, err := col.GetValueAtIndex(ndx)
-			if err != nil {
-				return nil, err
-			}
-			row = append(row, val)
-		case reflect.Int64:
-			col := d.bigIntColumns[columnName]
-			val, err := col.GetValueAtIndex(ndx)
-			if err != nil {
-				return nil, err
-			}
-			row = append(row, val)
-		case reflect.Float64:
-			col := d.floatColumns[columnName]
-			val, err := col.GetValueAtIndex(ndx)
-			if err != nil {
-				return nil, err
-			}
-			row = append(row, val)
-		default:
-			return nil, UnsupportedType{colType}
+	for _, series := range d.columns[:columnCount] {
+		if series.IsNull(ndx) {
+			row = append(row, "")
+			continue
 		}
+		if displayer, ok := series.(displayer); ok {
+			row = append(row, displayer.display(ndx))
+			continue
+		}
+		row = append(row, series.Format(ndx))
 	}
 	return row, nil
 }
@@ -346,7 +534,7 @@ func (d Dataframe) createRowFromNdx(ndx, columnCount int) ([]interface{}, error)
 func (d Dataframe) Table(columnCount, rowCount int) (table.Writer, error) {
 	columnStop := columnCount
 	if columnCount <= 0 {
-		columnStop = len(d.columnOrder)
+		columnStop = len(d.columns)
 	}
 	rowStop := rowCount
 	if rowCount <= 0 {
@@ -373,10 +561,10 @@ func (d Dataframe) Length() int {
 // String is the stringer interface so it can be printed
 func (d Dataframe) String() string {
 	returnString := ""
-	returnString += fmt.Sprintf("Number of Columns: %d\n", len(d.columnOrder))
+	returnString += fmt.Sprintf("Number of Columns: %d\n", len(d.columns))
 	returnString += fmt.Sprintf("Number of Rows:    %d\n", d.numberRows)
 	minRows := getMin(10, d.numberRows)
-	minCols := getMin(10, len(d.columnOrder))
+	minCols := getMin(10, len(d.columns))
 	table, err := d.Table(minCols, minRows)
 	if err != nil {
 		returnString += fmt.Sprintf("Cannot Print Table: %s\n", err)
@@ -389,32 +577,121 @@ func (d Dataframe) String() string {
 // Names is a function that will return the names of the dataframe
 // in order
 func (d Dataframe) Names() []string {
-	return d.columnOrder
+	names := make([]string, 0, len(d.columns))
+	for _, series := range d.columns {
+		names = append(names, series.Name())
+	}
+	return names
+}
+
+// Select will return a pointer to a new dataframe holding only the
+// named columns, in the order given.  The columns are copied, so that
+// appending to either dataframe never changes the other
+func (d Dataframe) Select(columns ...string) (*Dataframe, error) {
+	df := New()
+	for _, columnName := range columns {
+		series, err := d.GetSeries(columnName)
+		if err != nil {
+			return nil, err
+		}
+		if df.hasColumn(columnName) {
+			return nil, ColumnAlreadyExists{columnName}
+		}
+		copied, err := copySeries(series)
+		if err != nil {
+			return nil, fmt.Errorf("unable to copy column %s: %w", columnName, err)
+		}
+		df.columnIndex[columnName] = len(df.columns)
+		df.columns = append(df.columns, copied)
+	}
+	df.numberRows = d.numberRows
+	return df, nil
 }
 
 // GetColumnType takes a column name (string) and returns the type of that
 // column.  This is useful for determining what function to use to grab a
-// Column with.  If the Column doesn't exist, it returns a MissingColumnError
+// Column with.  If the Column doesn't exist, it returns reflect.Invalid and
+// a MissingColumnError
 func (d Dataframe) GetColumnType(columnName string) (reflect.Kind, error) {
-	columnType, ok := d.columnTypes[columnName]
-	if !ok {
-		return reflect.Int, MissingColumnError{ColumnName: columnName}
+	series, err := d.GetSeries(columnName)
+	if err != nil {
+		return reflect.Invalid, err
 	}
-	return columnType, nil
+	return series.Type(), nil
 }
 
-// WriteCSV is a function that takes a filename and returns an error
-// if the file cannot be written.
-func (d Dataframe) WriteCSV(filename string) error {
-	table, err := d.Table(0, 0)
-	if err != nil {
-		return fmt.Errorf("error during table construction: %w", err)
-	}
-	f, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("could not open file %s for writing: %w", filename, err)
+// GetSeries returns the named column as a Series, which is shared with
+// this dataframe rather than copied.  If the Column doesn't exist, it
+// returns a MissingColumnError
+func (d Dataframe) GetSeries(columnName string) (Series, error) {
+	series := d.series(columnName)
+	if series == nil {
+		return nil, MissingColumnError{ColumnName: columnName}
+	}
+	return series, nil
+}
+
+// series returns the named column, or nil if there is no such column
+func (d Dataframe) series(columnName string) Series {
+	ndx, ok := d.columnIndex[columnName]
+	if !ok {
+		return nil
 	}
-	defer f.Close()
-	f.WriteString(table.RenderCSV())
-	return nil
+	return d.columns[ndx]
+}
+
+func (d Dataframe) hasColumn(columnName string) bool {
+	_, ok := d.columnIndex[columnName]
+	return ok
+}
+
+// columnType returns the type of the named column, or reflect.Invalid if
+// there is no such column
+func (d Dataframe) columnType(columnName string) reflect.Kind {
+	series := d.series(columnName)
+	if series == nil {
+		return reflect.Invalid
+	}
+	return series.Type()
+}
+
+// replaceSeries swaps the column with the same name for the one given,
+// which must have the same length
+func (d *Dataframe) replaceSeries(series Series) {
+	d.columns[d.columnIndex[series.Name()]] = series
+}
+
+// The typed accessors below must only be called once the type of the
+// column has been checked
+
+func (d Dataframe) intColumn(columnName string) *Column[int] {
+	return d.series(columnName).(columnSeries[int]).Column
+}
+
+func (d Dataframe) bigIntColumn(columnName string) *Column[int64] {
+	return d.series(columnName).(columnSeries[int64]).Column
+}
+
+func (d Dataframe) floatColumn(columnName string) *Column[float64] {
+	return d.series(columnName).(columnSeries[float64]).Column
+}
+
+func (d Dataframe) stringColumn(columnName string) *Column[string] {
+	return d.series(columnName).(columnSeries[string]).Column
+}
+
+func (d Dataframe) boolColumn(columnName string) *BoolColumn {
+	return d.series(columnName).(boolSeries).BoolColumn
+}
+
+func (d Dataframe) timeColumn(columnName string) *TimeColumn {
+	return d.series(columnName).(timeSeries).TimeColumn
+}
+
+func (d Dataframe) decimalColumn(columnName string) *DecimalColumn {
+	return d.series(columnName).(decimalSeries).DecimalColumn
+}
+
+func (d Dataframe) categoricalColumn(columnName string) *CategoricalColumn {
+	return d.series(columnName).(categoricalSeries).CategoricalColumn
 }
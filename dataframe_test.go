@@ -0,0 +1,235 @@
+package dataframe
+
+import (
+	"errors"
+	"math"
+	"os"
+	"testing"
+)
+
+func createTestDataframe(t *testing.T) *Dataframe {
+	tempDir, testFileName, err := createTestCSV("dataframe_test.csv")
+	if err != nil {
+		t.Fatalf("unable to generate test data: %s", err)
+		return nil
+	}
+	defer os.RemoveAll(tempDir)
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return nil
+	}
+	df, err := FromCSV(testFileName, *schema, true)
+	if err != nil {
+		t.Fatalf("unable to create dataframe from file: %s", err)
+		return nil
+	}
+	return df
+}
+
+// testColumn describes a column for buildTestDataframe.  Values is a
+// slice of string, int, int64, float64 or bool, and a nil Valid makes
+// every value present
+type testColumn struct {
+	Name   string
+	Values interface{}
+	Valid  []bool
+}
+
+// buildTestDataframe creates a dataframe holding the columns described,
+// in order, so each test file can declare its data as a table rather
+// than building every column by hand
+func buildTestDataframe(t *testing.T, columns ...testColumn) *Dataframe {
+	t.Helper()
+	df := New()
+	for _, column := range columns {
+		var err error
+		switch values := column.Values.(type) {
+		case []string:
+			err = addTestColumn(column.Name, values, column.Valid, df.AddStringColumn)
+		case []int:
+			err = addTestColumn(column.Name, values, column.Valid, df.AddIntColumn)
+		case []int64:
+			err = addTestColumn(column.Name, values, column.Valid, df.AddBigIntColumn)
+		case []float64:
+			err = addTestColumn(column.Name, values, column.Valid, df.AddFloatColumn)
+		case []bool:
+			var col *BoolColumn
+			col, err = NewNullableBoolColumn(column.Name, values, allValid(column.Valid, len(values)))
+			if err == nil {
+				err = df.AddBoolColumn(*col)
+			}
+		default:
+			t.Fatalf("unsupported values %T for test column %s", column.Values, column.Name)
+		}
+		if err != nil {
+			t.Fatalf("unable to add test column %s: %s", column.Name, err)
+		}
+	}
+	return df
+}
+
+func addTestColumn[T Columnable](columnName string, values []T, valid []bool, add func(Column[T]) error) error {
+	col, err := NewNullableColumn(columnName, values, allValid(valid, len(values)))
+	if err != nil {
+		return err
+	}
+	return add(*col)
+}
+
+func allValid(valid []bool, length int) []bool {
+	if valid != nil {
+		return valid
+	}
+	valid = make([]bool, length)
+	for ndx := range valid {
+		valid[ndx] = true
+	}
+	return valid
+}
+
+func TestDataframeFilter(t *testing.T) {
+	df := createTestDataframe(t)
+	testCases := []struct {
+		columnName     string
+		operation      FilterType
+		values         []interface{}
+		expectedLength int
+	}{
+		{"Close", Greater, []interface{}{17.70}, 3},
+		{"Close", GreaterEq, []interface{}{17.72}, 3},
+		{"Close", Lesser, []interface{}{17.68}, 3},
+		{"Close", LesserEq, []interface{}{17.68}, 4},
+		{"Close", Equal, []interface{}{17.74}, 2},
+		{"Close", NotEqual, []interface{}{17.74}, 5},
+		{"Volume", Between, []interface{}{150000, 180000}, 3},
+		{"Volume", Greater, []interface{}{int64(150000)}, 5},
+		{"Transactions", In, []interface{}{452, 535, 1}, 2},
+		{"Symbol", Equal, []interface{}{"DFRAME"}, 7},
+		{"Symbol", Equal, []interface{}{"NOTHERE"}, 0},
+	}
+	for _, tc := range testCases {
+		filtered, err := df.Filter(tc.columnName, tc.operation, tc.values...)
+		if err != nil {
+			t.Errorf("unable to filter %s with %s %v: %s", tc.columnName, tc.operation, tc.values, err)
+			continue
+		}
+		if filtered.Length() != tc.expectedLength {
+			t.Errorf("expected %d rows when filtering %s with %s %v, but found %d", tc.expectedLength, tc.columnName, tc.operation, tc.values, filtered.Length())
+		}
+		if len(filtered.Names()) != len(df.Names()) {
+			t.Errorf("expected %d columns after filtering, but found %d", len(df.Names()), len(filtered.Names()))
+		}
+	}
+	filtered, err := df.Filter("Close", Greater, 17.70)
+	if err != nil {
+		t.Fatalf("unable to filter dataframe: %s", err)
+		return
+	}
+	testIntHelper(t, "Volume", 0, 151971, filtered)
+	testFloatHelper(t, "Open", 1, 17.68, filtered)
+	testBigIntHelper(t, "Transactions", 2, 431, filtered)
+}
+
+func TestDataframeFilterErrors(t *testing.T) {
+	df := createTestDataframe(t)
+	if _, err := df.Filter("NotAColumn", Equal, 1); err == nil {
+		t.Errorf("expected an error when filtering a missing column")
+	}
+	if _, err := df.Filter("Volume", Equal, "a string"); err == nil {
+		t.Errorf("expected an error when filtering an int column with a string")
+	}
+	if _, err := df.Filter("Volume", Equal, 1.5); err == nil {
+		t.Errorf("expected an error when filtering an int column with a fractional float")
+	}
+	if _, err := df.Filter("Volume", Between, 1); err == nil {
+		t.Errorf("expected an error when filtering with Between and a single value")
+	}
+	if _, err := df.Filter("Volume", Equal, math.NaN()); !errors.Is(err, ErrWrongColumnType) {
+		t.Errorf("expected NaN to be rejected for an int column, but found %v", err)
+	}
+	filtered, err := df.Filter("Close", Equal, float32(math.NaN()))
+	if err != nil {
+		t.Errorf("expected a float32 NaN to be accepted for a float column, but found %s", err)
+	} else if filtered.Length() != 0 {
+		t.Errorf("expected NaN to match no rows, but found %d", filtered.Length())
+	}
+}
+
+func TestDataframeWhere(t *testing.T) {
+	df := createTestDataframe(t)
+	closedUp, err := df.CompareColumns("Close", Greater, "Open")
+	if err != nil {
+		t.Fatalf("unable to compare Close to Open: %s", err)
+		return
+	}
+	highVolume, err := df.Compare("Volume", Greater, 150000)
+	if err != nil {
+		t.Fatalf("unable to compare Volume: %s", err)
+		return
+	}
+	mask, err := closedUp.And(highVolume)
+	if err != nil {
+		t.Fatalf("unable to combine masks: %s", err)
+		return
+	}
+	filtered, err := df.Where(mask)
+	if err != nil {
+		t.Fatalf("unable to apply mask: %s", err)
+		return
+	}
+	if filtered.Length() != 3 {
+		t.Errorf("expected 3 rows to close up on high volume, but found %d", filtered.Length())
+	}
+	testIntHelper(t, "Volume", 0, 151971, filtered)
+	if _, err := df.Where(NewMask(3)); err == nil {
+		t.Errorf("expected an error when using a mask of the wrong length")
+	}
+	if _, err := df.CompareColumns("Close", Greater, "Volume"); err == nil {
+		t.Errorf("expected an error when comparing columns of different types")
+	}
+}
+
+func TestDataframeSlice(t *testing.T) {
+	df := createTestDataframe(t)
+	sliced, err := df.Slice(1, 3)
+	if err != nil {
+		t.Fatalf("unable to slice dataframe: %s", err)
+		return
+	}
+	if sliced.Length() != 2 {
+		t.Errorf("expected 2 rows in the slice, but found %d", sliced.Length())
+	}
+	if len(sliced.Names()) != len(df.Names()) {
+		t.Errorf("expected the slice to keep every column, but found %v", sliced.Names())
+	}
+	testFloatHelper(t, "High", 1, 17.755, sliced)
+	testBigIntHelper(t, "WindowStart", 1, 16385076000, sliced)
+	if len(df.Names()) != len(testFileSchemaDefs) {
+		t.Errorf("expected slicing to leave the source columns alone, but found %v", df.Names())
+	}
+	if _, err := df.Slice(2, df.Length()+1); err == nil {
+		t.Errorf("expected an error when slicing past the end")
+	}
+}
+
+func TestDataframeSelectCopiesColumns(t *testing.T) {
+	df := createTestDataframe(t)
+	selected, err := df.Select("Volume", "Symbol")
+	if err != nil {
+		t.Fatalf("unable to select columns: %s", err)
+		return
+	}
+	if err := selected.ParseValue("Volume", "42"); err != nil {
+		t.Fatalf("unable to parse a value into the selection: %s", err)
+		return
+	}
+	if err := selected.AppendNull("Symbol"); err != nil {
+		t.Fatalf("unable to append a null to the selection: %s", err)
+		return
+	}
+	if err := df.IsValid(); err != nil {
+		t.Errorf("expected appending to the selection to leave the source alone: %s", err)
+	}
+	testIntHelper(t, "Volume", 0, 171463, selected)
+}
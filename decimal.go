@@ -0,0 +1,526 @@
+package dataframe
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MaxDecimalScale is the largest number of decimal places a Decimal
+// column can hold
+const MaxDecimalScale = 18
+
+// DecimalValue is an exact fixed point number, Unscaled / 10^Scale, so
+// 17.675 is DecimalValue{17675, 3}
+type DecimalValue struct {
+	Unscaled int64
+	Scale    int
+}
+
+func pow10(scale int) int64 {
+	result := int64(1)
+	for ; scale > 0; scale-- {
+		result *= 10
+	}
+	return result
+}
+
+// ParseDecimal parses text such as "-17.675" into a DecimalValue with as
+// many decimal places as the text has
+func ParseDecimal(value string) (DecimalValue, error) {
+	_, fraction, _ := strings.Cut(value, ".")
+	scale := len(fraction)
+	if scale > MaxDecimalScale {
+		return DecimalValue{}, &strconv.NumError{Func: "ParseDecimal", Num: value, Err: strconv.ErrRange}
+	}
+	unscaled, err := parseDecimal(value, scale)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	return DecimalValue{unscaled, scale}, nil
+}
+
+// parseDecimal parses text into an unscaled value with the given scale.
+// Digits past the scale must be zeros, so that no value is ever rounded
+func parseDecimal(value string, scale int) (int64, error) {
+	syntaxErr := &strconv.NumError{Func: "ParseDecimal", Num: value, Err: strconv.ErrSyntax}
+	digits := strings.TrimSpace(value)
+	negative := strings.HasPrefix(digits, "-")
+	if negative || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole == "" && fraction == "" {
+		return 0, syntaxErr
+	}
+	if extra := strings.TrimRight(fraction[min(scale, len(fraction)):], "0"); extra != "" {
+		return 0, fmt.Errorf("%s has more than %d decimal places: %w", value, scale, strconv.ErrRange)
+	}
+	fraction = fraction[:min(scale, len(fraction))]
+	fraction += strings.Repeat("0", scale-len(fraction))
+	// The magnitude is built as a uint64 so that math.MinInt64, whose
+	// magnitude is one more than math.MaxInt64, can still be parsed
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	var magnitude uint64
+	for _, r := range whole + fraction {
+		if r < '0' || r > '9' {
+			return 0, syntaxErr
+		}
+		if magnitude > (limit-uint64(r-'0'))/10 {
+			return 0, &strconv.NumError{Func: "ParseDecimal", Num: value, Err: strconv.ErrRange}
+		}
+		magnitude = magnitude*10 + uint64(r-'0')
+	}
+	if negative {
+		return -int64(magnitude), nil
+	}
+	return int64(magnitude), nil
+}
+
+// formatDecimal renders an unscaled value with exactly scale decimal
+// places
+func formatDecimal(unscaled int64, scale int) string {
+	if scale == 0 {
+		return strconv.FormatInt(unscaled, 10)
+	}
+	sign := ""
+	magnitude := strconv.FormatUint(uint64(unscaled), 10)
+	if unscaled < 0 {
+		sign = "-"
+		magnitude = strconv.FormatUint(uint64(-unscaled), 10)
+	}
+	if len(magnitude) <= scale {
+		magnitude = strings.Repeat("0", scale-len(magnitude)+1) + magnitude
+	}
+	split := len(magnitude) - scale
+	return sign + magnitude[:split] + "." + magnitude[split:]
+}
+
+// String renders the value with exactly Scale decimal places
+func (d DecimalValue) String() string {
+	return formatDecimal(d.Unscaled, d.Scale)
+}
+
+// Float64 returns the nearest float64 to the value
+func (d DecimalValue) Float64() float64 {
+	value, _ := strconv.ParseFloat(d.String(), 64)
+	return value
+}
+
+// Rescale returns the same value with a different number of decimal
+// places.  Reducing the scale returns an error unless the dropped digits
+// are zeros, and increasing it returns an error if the value no longer
+// fits
+func (d DecimalValue) Rescale(scale int) (DecimalValue, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return DecimalValue{}, fmt.Errorf("scale %d must be between 0 and %d", scale, MaxDecimalScale)
+	}
+	if scale < d.Scale {
+		factor := pow10(d.Scale - scale)
+		if d.Unscaled%factor != 0 {
+			return DecimalValue{}, fmt.Errorf("%s has more than %d decimal places: %w", d, scale, strconv.ErrRange)
+		}
+		return DecimalValue{d.Unscaled / factor, scale}, nil
+	}
+	factor := pow10(scale - d.Scale)
+	if d.Unscaled > math.MaxInt64/factor || d.Unscaled < math.MinInt64/factor {
+		return DecimalValue{}, fmt.Errorf("%s does not fit with %d decimal places: %w", d, scale, strconv.ErrRange)
+	}
+	return DecimalValue{d.Unscaled * factor, scale}, nil
+}
+
+// Add returns the exact sum of two values, using the larger of the two
+// scales.  It returns an error if the sum does not fit
+func (d DecimalValue) Add(other DecimalValue) (DecimalValue, error) {
+	scale := max(d.Scale, other.Scale)
+	left, err := d.Rescale(scale)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	right, err := other.Rescale(scale)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	sum := left.Unscaled + right.Unscaled
+	if (right.Unscaled > 0 && sum < left.Unscaled) || (right.Unscaled < 0 && sum > left.Unscaled) {
+		return DecimalValue{}, fmt.Errorf("sum of %s and %s overflows: %w", d, other, strconv.ErrRange)
+	}
+	return DecimalValue{sum, scale}, nil
+}
+
+// Sub returns the exact difference of two values, using the larger of
+// the two scales
+func (d DecimalValue) Sub(other DecimalValue) (DecimalValue, error) {
+	if other.Unscaled == math.MinInt64 {
+		return DecimalValue{}, fmt.Errorf("negating %s overflows: %w", other, strconv.ErrRange)
+	}
+	return d.Add(DecimalValue{-other.Unscaled, other.Scale})
+}
+
+// sign returns -1, 0 or 1 depending on the sign of the value
+func (d DecimalValue) sign() int {
+	return cmp.Compare(d.Unscaled, 0)
+}
+
+// split returns the integer part of the value and its fraction as a
+// number of units at the given scale, which must be at least Scale.  Both
+// parts have the same sign as the value
+func (d DecimalValue) split(scale int) (int64, int64) {
+	factor := pow10(d.Scale)
+	return d.Unscaled / factor, d.Unscaled % factor * pow10(scale-d.Scale)
+}
+
+// Cmp compares two values, returning -1, 0 or 1.  It never rescales
+// either value, so it works for every pair of values even when one of
+// them does not fit at the scale of the other
+func (d DecimalValue) Cmp(other DecimalValue) int {
+	if result := cmp.Compare(d.sign(), other.sign()); result != 0 {
+		return result
+	}
+	scale := max(d.Scale, other.Scale)
+	whole, fraction := d.split(scale)
+	otherWhole, otherFraction := other.split(scale)
+	if result := cmp.Compare(whole, otherWhole); result != 0 {
+		return result
+	}
+	return cmp.Compare(fraction, otherFraction)
+}
+
+// DecimalColumn holds exact fixed point values as unscaled int64s that
+// all share the column's Scale, so the int64 methods of the embedded
+// Column work on the raw values
+type DecimalColumn struct {
+	Column[int64]
+	Scale int
+}
+
+// GetDecimalAtIndex will fetch the value at the ndx provided.  If the
+// index is out of bounds, it will return an IndexOutOfBounds error
+func (c DecimalColumn) GetDecimalAtIndex(ndx int) (DecimalValue, error) {
+	unscaled, err := c.GetValueAtIndex(ndx)
+	if err != nil {
+		return DecimalValue{}, err
+	}
+	return DecimalValue{unscaled, c.Scale}, nil
+}
+
+// AppendDecimal will append the value provided to the column.  It
+// returns an error if the value has more decimal places than the column
+func (c *DecimalColumn) AppendDecimal(value DecimalValue) error {
+	rescaled, err := value.Rescale(c.Scale)
+	if err != nil {
+		return err
+	}
+	c.AppendValue(rescaled.Unscaled)
+	return nil
+}
+
+// Sum returns the exact sum of every value that is not null
+func (c DecimalColumn) Sum() (DecimalValue, error) {
+	sum := DecimalValue{0, c.Scale}
+	for ndx, unscaled := range c.data {
+		if c.IsNull(ndx) {
+			continue
+		}
+		var err error
+		sum, err = sum.Add(DecimalValue{unscaled, c.Scale})
+		if err != nil {
+			return DecimalValue{}, err
+		}
+	}
+	return sum, nil
+}
+
+// Float64Column returns the values converted to float64, keeping the
+// name and nulls, for statistics that cannot be exact
+func (c DecimalColumn) Float64Column() *Column[float64] {
+	newColumn := ConvertColumn[int64, float64](c.Column)
+	for ndx := range newColumn.data {
+		newColumn.data[ndx] = DecimalValue{c.data[ndx], c.Scale}.Float64()
+	}
+	return newColumn
+}
+
+func (c DecimalColumn) format(ndx int) string {
+	return formatDecimal(c.data[ndx], c.Scale)
+}
+
+// keyAt renders the value without trailing zeros, so that equal values
+// in columns of different scales produce the same key
+func (c DecimalColumn) keyAt(ndx int) string {
+	key := c.format(ndx)
+	if c.Scale > 0 {
+		key = strings.TrimSuffix(strings.TrimRight(key, "0"), ".")
+	}
+	return key
+}
+
+// convertDecimals converts values used against the column, which can be
+// DecimalValues, text, integers or floats, to unscaled values.  Values
+// with more decimal places than the column are rejected
+func (c DecimalColumn) convertDecimals(values []interface{}) ([]int64, error) {
+	converted := make([]int64, 0, len(values))
+	for _, value := range values {
+		var text string
+		switch v := value.(type) {
+		case DecimalValue:
+			text = v.String()
+		case string:
+			text = v
+		case int, int64, int32:
+			text = fmt.Sprint(v)
+		case float64:
+			text = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("value %v of type %T cannot be used with decimal column %s: %w", value, value, c.ColumnName, ErrWrongColumnType)
+		}
+		unscaled, err := parseDecimal(text, c.Scale)
+		if err != nil {
+			return nil, ParseError{-1, c.ColumnName, text, Decimal, err}
+		}
+		converted = append(converted, unscaled)
+	}
+	return converted, nil
+}
+
+// rescaled returns the column with a larger scale, for combining it
+// with a column that has more decimal places.  It returns an error if a
+// value no longer fits
+func (c DecimalColumn) rescaled(scale int) (*DecimalColumn, error) {
+	if scale < c.Scale {
+		return nil, fmt.Errorf("column %s has %d decimal places, which cannot be reduced to %d: %w", c.ColumnName, c.Scale, scale, ErrWrongColumnType)
+	}
+	factor := pow10(scale - c.Scale)
+	newColumn := c.wrap(c.clone())
+	newColumn.Scale = scale
+	for ndx, unscaled := range newColumn.data {
+		if unscaled > math.MaxInt64/factor || unscaled < math.MinInt64/factor {
+			return nil, fmt.Errorf("value %s of column %s does not fit %d decimal places: %w", c.format(ndx), c.ColumnName, scale, strconv.ErrRange)
+		}
+		newColumn.data[ndx] = unscaled * factor
+	}
+	return newColumn, nil
+}
+
+// CompareColumn evaluates the operation row by row against another
+// decimal column, lining up the scales of the two columns first
+func (c DecimalColumn) CompareColumn(operation FilterType, other DecimalColumn) (Mask, error) {
+	scale := max(c.Scale, other.Scale)
+	left, err := c.rescaled(scale)
+	if err != nil {
+		return Mask{}, err
+	}
+	right, err := other.rescaled(scale)
+	if err != nil {
+		return Mask{}, err
+	}
+	return left.Column.CompareColumn(operation, right.Column)
+}
+
+// wrap turns a column of raw values derived from this one back into a
+// decimal column with the same scale
+func (c DecimalColumn) wrap(col *Column[int64]) *DecimalColumn {
+	col.ColumnType = Decimal
+	return &DecimalColumn{Column: *col, Scale: c.Scale}
+}
+
+// Slice takes a start and stop parameter and returns a new column of
+// the same name and scale
+func (c DecimalColumn) Slice(start, stop int) (*DecimalColumn, error) {
+	col, err := c.Column.Slice(start, stop)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrap(col), nil
+}
+
+// Take returns a new column of the same name and scale holding the
+// values at the provided indices.  An index of -1 produces a null
+func (c DecimalColumn) Take(indices []int) (*DecimalColumn, error) {
+	col, err := c.Column.Take(indices)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrap(col), nil
+}
+
+// FillForward returns a new column where every null has been replaced
+// with the last value before it that was not null
+func (c DecimalColumn) FillForward() *DecimalColumn {
+	return c.wrap(c.Column.FillForward())
+}
+
+// FillBackward returns a new column where every null has been replaced
+// with the next value after it that is not null
+func (c DecimalColumn) FillBackward() *DecimalColumn {
+	return c.wrap(c.Column.FillBackward())
+}
+
+// Coalesce returns a new column where every null has been replaced with
+// the value at the same index in the other column, which cannot have
+// more decimal places than this one
+func (c DecimalColumn) Coalesce(other DecimalColumn) (*DecimalColumn, error) {
+	rescaled, err := other.rescaled(c.Scale)
+	if err != nil {
+		return nil, err
+	}
+	col, err := c.Column.Coalesce(rescaled.Column)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrap(col), nil
+}
+
+// NewDecimalColumn will create a new decimal column with the given scale
+// from existing values.  Use []DecimalValue{} as the data argument to
+// create an empty column
+func NewDecimalColumn(colName string, scale int, data []DecimalValue) (*DecimalColumn, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return nil, fmt.Errorf("scale %d of column %s must be between 0 and %d", scale, colName, MaxDecimalScale)
+	}
+	col := &DecimalColumn{
+		Column: Column[int64]{ColumnName: colName, ColumnType: Decimal},
+		Scale:  scale,
+	}
+	col.data = make([]int64, 0, len(data))
+	for _, value := range data {
+		err := col.AppendDecimal(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return col, nil
+}
+
+// decimalSeries is the Series for DecimalColumn
+type decimalSeries struct {
+	*DecimalColumn
+}
+
+func newDecimalSeries(columnName string) (Series, error) {
+	col, err := NewDecimalColumn(columnName, 0, []DecimalValue{})
+	if err != nil {
+		return nil, err
+	}
+	return decimalSeries{col}, nil
+}
+
+func (s decimalSeries) Name() string {
+	return s.ColumnName
+}
+
+func (s decimalSeries) Type() reflect.Kind {
+	return s.ColumnType
+}
+
+func (s decimalSeries) Len() int {
+	return s.Length()
+}
+
+func (s decimalSeries) Parse(value string) error {
+	val, err := parseDecimal(value, s.Scale)
+	if err != nil {
+		return err
+	}
+	s.AppendValue(val)
+	return nil
+}
+
+func (s decimalSeries) Format(ndx int) string {
+	return s.format(ndx)
+}
+
+func (s decimalSeries) Slice(start, stop int) (Series, error) {
+	col, err := s.DecimalColumn.Slice(start, stop)
+	if err != nil {
+		return nil, err
+	}
+	return decimalSeries{col}, nil
+}
+
+func (s decimalSeries) Take(indices []int) (Series, error) {
+	col, err := s.DecimalColumn.Take(indices)
+	if err != nil {
+		return nil, err
+	}
+	return decimalSeries{col}, nil
+}
+
+func (s decimalSeries) Rename(name string) Series {
+	col := s.wrap(s.clone())
+	col.ColumnName = name
+	return decimalSeries{col}
+}
+
+func (s decimalSeries) CompareValues(operation FilterType, values ...interface{}) (Mask, error) {
+	converted, err := s.convertDecimals(values)
+	if err != nil {
+		return Mask{}, err
+	}
+	return s.Compare(operation, converted...)
+}
+
+func (s decimalSeries) CompareSeries(operation FilterType, other Series) (Mask, error) {
+	otherSeries, ok := other.(decimalSeries)
+	if !ok {
+		return Mask{}, WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	return s.CompareColumn(operation, *otherSeries.DecimalColumn)
+}
+
+func (s decimalSeries) CompareRows(i, j int) int {
+	return s.compareRows(i, j)
+}
+
+func (s decimalSeries) Key(ndx int) string {
+	return s.keyAt(ndx)
+}
+
+// AppendSeries rescales the other column to the scale of this one,
+// returning an error if a value does not fit
+func (s decimalSeries) AppendSeries(other Series) error {
+	otherSeries, ok := other.(decimalSeries)
+	if !ok {
+		return WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	col, err := otherSeries.rescaled(s.Scale)
+	if err != nil {
+		return err
+	}
+	s.AppendColumn(col.Column)
+	return nil
+}
+
+func (s decimalSeries) display(ndx int) interface{} {
+	return s.format(ndx)
+}
+
+func (s decimalSeries) fillNA(value interface{}) (Series, error) {
+	converted, err := s.convertDecimals([]interface{}{value})
+	if err != nil {
+		return nil, err
+	}
+	return decimalSeries{s.wrap(s.Column.FillNull(converted[0]))}, nil
+}
+
+func (s decimalSeries) sumGroups(groups [][]int, outputName string) (Series, bool, error) {
+	sums, err := sumDecimalGroups(*s.DecimalColumn, groups, outputName)
+	if err != nil {
+		return nil, true, err
+	}
+	return decimalSeries{sums}, true, nil
+}
+
+func (s decimalSeries) float64Column() (*Column[float64], bool) {
+	return s.Float64Column(), true
+}
+
+func (s decimalSeries) interpolate() (Series, bool) {
+	return decimalSeries{s.wrap(InterpolateColumn(s.Column))}, true
+}
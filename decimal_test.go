@@ -0,0 +1,213 @@
+package dataframe
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecimalValue(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected DecimalValue
+		rendered string
+	}{
+		{"17.675", DecimalValue{17675, 3}, "17.675"},
+		{"-0.05", DecimalValue{-5, 2}, "-0.05"},
+		{"42", DecimalValue{42, 0}, "42"},
+		{".5", DecimalValue{5, 1}, "0.5"},
+		{"+5", DecimalValue{5, 0}, "5"},
+		{"-5", DecimalValue{-5, 0}, "-5"},
+		{"-9223372036854775808", DecimalValue{math.MinInt64, 0}, "-9223372036854775808"},
+		{"-9.223372036854775808", DecimalValue{math.MinInt64, 18}, "-9.223372036854775808"},
+	}
+	for _, test := range tests {
+		value, err := ParseDecimal(test.text)
+		if err != nil || value != test.expected {
+			t.Errorf("expected %s to parse as %v, but found %v (%v)", test.text, test.expected, value, err)
+		}
+		if value.String() != test.rendered {
+			t.Errorf("expected %v to render as %s, but found %s", value, test.rendered, value.String())
+		}
+	}
+	for _, text := range []string{"", "1.2.3", "abc", "9223372036854775808", "-9223372036854775809", "--5", "+-5", "-+5", "-"} {
+		if _, err := ParseDecimal(text); err == nil {
+			t.Errorf("expected %q to be rejected", text)
+		}
+	}
+	sum, err := DecimalValue{1, 1}.Add(DecimalValue{2, 1})
+	if err != nil || sum.String() != "0.3" {
+		t.Errorf("expected 0.1 + 0.2 to be exactly 0.3, but found %s (%v)", sum, err)
+	}
+	if _, err := (DecimalValue{9223372036854775807, 0}).Add(DecimalValue{1, 0}); err == nil {
+		t.Errorf("expected an overflowing sum to be rejected")
+	}
+	if _, err := (DecimalValue{1234, 3}).Rescale(2); err == nil {
+		t.Errorf("expected rescaling 1.234 to 2 places to be rejected")
+	}
+	rescaled, err := DecimalValue{-1200, 3}.Rescale(1)
+	if err != nil || rescaled != (DecimalValue{-12, 1}) {
+		t.Errorf("expected -1.200 to rescale to -1.2, but found %v (%v)", rescaled, err)
+	}
+	rescaled, err = DecimalValue{math.MinInt64 / 10, 0}.Rescale(1)
+	if err != nil || rescaled.Unscaled != math.MinInt64/10*10 {
+		t.Errorf("expected %d to rescale to 1 place, but found %v (%v)", int64(math.MinInt64/10), rescaled, err)
+	}
+	if _, err := (DecimalValue{math.MaxInt64 / 10, 0}).Rescale(2); err == nil {
+		t.Errorf("expected rescaling a value that no longer fits to be rejected")
+	}
+}
+
+func TestDecimalValueCmp(t *testing.T) {
+	tests := []struct {
+		left, right DecimalValue
+		expected    int
+	}{
+		{DecimalValue{150, 2}, DecimalValue{15, 1}, 0},
+		{DecimalValue{1, 2}, DecimalValue{1e17, 0}, -1},
+		{DecimalValue{1e17, 0}, DecimalValue{1, 2}, 1},
+		{DecimalValue{-1, 2}, DecimalValue{-1e17, 0}, 1},
+		{DecimalValue{math.MaxInt64, 18}, DecimalValue{9, 0}, 1},
+		{DecimalValue{math.MaxInt64, 18}, DecimalValue{10, 0}, -1},
+		{DecimalValue{math.MinInt64, 18}, DecimalValue{-9, 0}, -1},
+		{DecimalValue{math.MinInt64, 0}, DecimalValue{math.MaxInt64, 18}, -1},
+		{DecimalValue{math.MaxInt64, 0}, DecimalValue{math.MinInt64, 0}, 1},
+		{DecimalValue{-5, 1}, DecimalValue{3, 1}, -1},
+		{DecimalValue{0, 3}, DecimalValue{0, 0}, 0},
+		{DecimalValue{123456789, 9}, DecimalValue{1234567891, 10}, -1},
+	}
+	for _, test := range tests {
+		if result := test.left.Cmp(test.right); result != test.expected {
+			t.Errorf("expected comparing %s to %s to return %d, but found %d", test.left, test.right, test.expected, result)
+		}
+	}
+}
+
+func TestDecimalColumns(t *testing.T) {
+	content := `ticker,price,fee
+AAA,17.50,0.10
+BBB,0.20,
+AAA,100,0.05
+`
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "ticker", ColumnType: reflect.String},
+		{ColumnName: "price", ColumnType: Decimal, Scale: 2},
+		{ColumnName: "fee", ColumnType: Decimal, Scale: 4},
+	})
+	if err != nil {
+		t.Fatalf("unable to create schema with decimal columns: %s", err)
+		return
+	}
+	df, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read decimal columns: %s", err)
+		return
+	}
+	price, err := df.GetDecimalValue("price", 2)
+	if err != nil || price != (DecimalValue{10000, 2}) {
+		t.Errorf("expected a price of 100.00, but found %s (%v)", price, err)
+	}
+	if null, _ := df.IsNull("fee", 1); !null {
+		t.Errorf("expected an empty decimal to be null")
+	}
+	if _, err := df.GetDecimalValue("ticker", 0); !errors.Is(err, ErrWrongColumnType) {
+		t.Errorf("expected a wrong column type error, but found %v", err)
+	}
+
+	cheap, err := df.Filter("price", Lesser, "17.5")
+	if err != nil {
+		t.Fatalf("unable to filter decimals with text: %s", err)
+		return
+	}
+	testStringHelper(t, "ticker", 0, "BBB", cheap)
+	if _, err := df.Filter("price", Equal, 0.205); err == nil {
+		t.Errorf("expected a value with too many decimal places to be rejected")
+	}
+	mask, err := df.CompareColumns("fee", Lesser, "price")
+	if err != nil {
+		t.Fatalf("unable to compare decimal columns of different scales: %s", err)
+		return
+	}
+	if got := mask.Indices(); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("expected the fee to be below the price at [0 2], but found %v", got)
+	}
+
+	grouped, err := df.GroupBy("ticker")
+	if err != nil {
+		t.Fatalf("unable to group decimal columns: %s", err)
+		return
+	}
+	sums, err := grouped.Sum()
+	if err != nil {
+		t.Fatalf("unable to sum decimal columns: %s", err)
+		return
+	}
+	total, err := sums.GetDecimalValue("fee", 0)
+	if err != nil || total.String() != "0.1500" {
+		t.Errorf("expected the AAA fees to sum to exactly 0.1500, but found %s (%v)", total, err)
+	}
+
+	var buffer bytes.Buffer
+	if err := df.WriteCSVTo(&buffer, CSVWriteOptions{}); err != nil {
+		t.Fatalf("unable to write decimal columns: %s", err)
+		return
+	}
+	expected := `ticker,price,fee
+AAA,17.50,0.1000
+BBB,0.20,
+AAA,100.00,0.0500
+`
+	if buffer.String() != expected {
+		t.Errorf("expected decimals to be written with their scale, but found\n%s", buffer.String())
+	}
+	table, err := df.Table(0, 0)
+	if err != nil {
+		t.Fatalf("unable to render table: %s", err)
+		return
+	}
+	if !strings.Contains(table.Render(), "17.50") {
+		t.Errorf("expected the table to render decimals with their scale")
+	}
+
+	_, err = ReadCSV(strings.NewReader(strings.Replace(content, "17.50", "17.505", 1)), *schema, CSVOptions{HasHeader: true})
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) || parseErr.Kind != Decimal || parseErr.Row != 0 {
+		t.Errorf("expected a parse error on row 0 for a value past the scale, but found %v", err)
+	}
+}
+
+func TestConcatDecimalColumns(t *testing.T) {
+	first, err := NewDecimalColumn("amount", 1, []DecimalValue{{15, 1}})
+	if err != nil {
+		t.Fatalf("unable to create decimal column: %s", err)
+		return
+	}
+	second, err := NewDecimalColumn("amount", 3, []DecimalValue{{1234, 3}})
+	if err != nil {
+		t.Fatalf("unable to create decimal column: %s", err)
+		return
+	}
+	left, right := New(), New()
+	if err := left.AddDecimalColumn(*first); err != nil {
+		t.Fatalf("unable to add decimal column: %s", err)
+		return
+	}
+	if err := right.AddDecimalColumn(*second); err != nil {
+		t.Fatalf("unable to add decimal column: %s", err)
+		return
+	}
+	df, err := Concat(left, right)
+	if err != nil {
+		t.Fatalf("unable to concatenate decimal columns: %s", err)
+		return
+	}
+	for ndx, expected := range []string{"1.500", "1.234"} {
+		value, err := df.GetDecimalValue("amount", ndx)
+		if err != nil || value.String() != expected {
+			t.Errorf("expected %s at index %d, but found %s (%v)", expected, ndx, value, err)
+		}
+	}
+}
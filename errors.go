@@ -1,19 +1,47 @@
 package dataframe
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
+// Sentinel errors for each kind of failure, so that callers can branch
+// with errors.Is without knowing the concrete error type.  Every error
+// type below matches its sentinel
+var (
+	ErrMissingColumn    = errors.New("missing column")
+	ErrWrongColumnType  = errors.New("wrong column type")
+	ErrRowCountMismatch = errors.New("row count mismatch")
+	ErrUnsupportedType  = errors.New("unsupported type")
+	ErrColumnExists     = errors.New("column already exists")
+	ErrIndexOutOfBounds = errors.New("index out of bounds")
+	ErrMaskLength       = errors.New("mask length mismatch")
+	ErrMissingHeader    = errors.New("missing header column")
+	ErrParse            = errors.New("unable to parse value")
+)
+
+// MissingColumnError is returned when a column does not exist.  Type is
+// the type that was requested, or reflect.Invalid when any type would do
 type MissingColumnError struct {
 	ColumnName string
 	Type       reflect.Kind
 }
 
 func (m MissingColumnError) Error() string {
-	return fmt.Sprintf("dataframe has no %s type column called %s", m.Type, m.ColumnName)
+	if m.Type == reflect.Invalid {
+		return fmt.Sprintf("dataframe has no column called %s", m.ColumnName)
+	}
+	return fmt.Sprintf("dataframe has no %s type column called %s", kindName(m.Type), m.ColumnName)
 }
 
+func (m MissingColumnError) Is(target error) bool {
+	return target == ErrMissingColumn
+}
+
+// WrongColumnTypeError is returned when a column is used as CorrectType,
+// the type the operation requires, but is actually CurrentType
 type WrongColumnTypeError struct {
 	ColumnName  string
 	CorrectType reflect.Kind
@@ -21,7 +49,11 @@ type WrongColumnTypeError struct {
 }
 
 func (w WrongColumnTypeError) Error() string {
-	return fmt.Sprintf("requested column %s of type %s is actually type %s", w.ColumnName, w.CurrentType, w.CorrectType)
+	return fmt.Sprintf("requested column %s of type %s is actually type %s", w.ColumnName, kindName(w.CorrectType), kindName(w.CurrentType))
+}
+
+func (w WrongColumnTypeError) Is(target error) bool {
+	return target == ErrWrongColumnType
 }
 
 type RowCountMismatchError struct {
@@ -34,12 +66,20 @@ func (r RowCountMismatchError) Error() string {
 	return fmt.Sprintf("column %s has %d rows, but current dataframe requires %d rows", r.ColumnName, r.DoesHave, r.ShouldHave)
 }
 
+func (r RowCountMismatchError) Is(target error) bool {
+	return target == ErrRowCountMismatch
+}
+
 type UnsupportedType struct {
 	ColumnType reflect.Kind
 }
 
 func (u UnsupportedType) Error() string {
-	return fmt.Sprintf("type %s is unsupported", u.ColumnType)
+	return fmt.Sprintf("type %s is unsupported", kindName(u.ColumnType))
+}
+
+func (u UnsupportedType) Is(target error) bool {
+	return target == ErrUnsupportedType
 }
 
 type ColumnAlreadyExists struct {
@@ -50,6 +90,10 @@ func (c ColumnAlreadyExists) Error() string {
 	return fmt.Sprintf("Column %s already exists in the dataframe", c.ColumnName)
 }
 
+func (c ColumnAlreadyExists) Is(target error) bool {
+	return target == ErrColumnExists
+}
+
 type IndexOutOfBounds struct {
 	ColumnName  string
 	BrokenIndex int
@@ -59,3 +103,60 @@ type IndexOutOfBounds struct {
 func (i IndexOutOfBounds) Error() string {
 	return fmt.Sprintf("requested index %d is out of bounds for column %s which has max index %d", i.BrokenIndex, i.ColumnName, i.MaxIndex)
 }
+
+func (i IndexOutOfBounds) Is(target error) bool {
+	return target == ErrIndexOutOfBounds
+}
+
+type MaskLengthMismatchError struct {
+	Expected int
+	Actual   int
+}
+
+func (m MaskLengthMismatchError) Error() string {
+	return fmt.Sprintf("mask has %d entries, but %d entries are required", m.Actual, m.Expected)
+}
+
+func (m MaskLengthMismatchError) Is(target error) bool {
+	return target == ErrMaskLength
+}
+
+type MissingHeaderError struct {
+	ColumnName string
+	Header     []string
+}
+
+func (m MissingHeaderError) Error() string {
+	return fmt.Sprintf("schema column %s was not found in the header columns %s", m.ColumnName, strings.Join(m.Header, ", "))
+}
+
+func (m MissingHeaderError) Is(target error) bool {
+	return target == ErrMissingHeader
+}
+
+// ParseError is returned when a CSV cell or a value given to ParseValue
+// cannot be converted to the type of its column.  Row is the data row
+// the value came from, or -1 when it did not come from a file
+type ParseError struct {
+	Row    int
+	Column string
+	Value  string
+	Kind   reflect.Kind
+	Err    error
+}
+
+func (p ParseError) Error() string {
+	location := fmt.Sprintf("column %s", p.Column)
+	if p.Row >= 0 {
+		location = fmt.Sprintf("column %s on row %d", p.Column, p.Row)
+	}
+	return fmt.Sprintf("unable to parse %q into %s for %s: %s", p.Value, kindName(p.Kind), location, p.Err)
+}
+
+func (p ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+func (p ParseError) Unwrap() error {
+	return p.Err
+}
@@ -0,0 +1,61 @@
+package dataframe
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestErrorTaxonomy(t *testing.T) {
+	df := createTestDataframe(t)
+	columnType, err := df.GetColumnType("missing")
+	if columnType != reflect.Invalid || !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("expected an invalid type and a missing column error, but found %s and %v", columnType, err)
+	}
+	if err.Error() != "dataframe has no column called missing" {
+		t.Errorf("unexpected missing column message: %s", err)
+	}
+
+	_, err = df.GetFloatValue("Volume", 0)
+	var wrongType WrongColumnTypeError
+	if !errors.As(err, &wrongType) || !errors.Is(err, ErrWrongColumnType) {
+		t.Fatalf("expected a wrong column type error, but found %v", err)
+		return
+	}
+	if err.Error() != "requested column Volume of type float64 is actually type int" {
+		t.Errorf("unexpected wrong column type message: %s", err)
+	}
+	if _, err := df.GetIntValue("Volume", 100); !errors.Is(err, ErrIndexOutOfBounds) {
+		t.Errorf("expected an index out of bounds error, but found %v", err)
+	}
+
+	err = df.ParseValue("Volume", "lots")
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) || !errors.Is(err, ErrParse) || !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("expected a parse error, but found %v", err)
+		return
+	}
+	if parseErr.Row != -1 || parseErr.Column != "Volume" || parseErr.Value != "lots" || parseErr.Kind != reflect.Int {
+		t.Errorf("unexpected parse error %+v", parseErr)
+	}
+	if err := df.AppendNull("missing"); !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("expected a missing column error, but found %v", err)
+	}
+
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	content := strings.Replace(testCSV, "17.72", "17..72", 1)
+	_, err = ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true})
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a parse error from the csv, but found %v", err)
+		return
+	}
+	if parseErr.Row != 2 || parseErr.Column != "Close" || parseErr.Value != "17..72" || parseErr.Kind != reflect.Float64 {
+		t.Errorf("unexpected parse error %+v", parseErr)
+	}
+}
@@ -2,7 +2,12 @@ module github.com/kcphysics/dataframe
 
 go 1.21.5
 
-require github.com/jedib0t/go-pretty v4.3.0+incompatible
+require (
+	github.com/dsnet/compress v0.0.1
+	github.com/jedib0t/go-pretty v4.3.0+incompatible
+	github.com/klauspost/compress v1.17.9
+	github.com/ulikunitz/xz v0.5.12
+)
 
 require (
 	github.com/asaskevich/govalidator v0.0.0-20230301143203-a9d515a09cc2 // indirect
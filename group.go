@@ -0,0 +1,425 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// AggregationType names the calculation used to reduce each group to a
+// single value
+type AggregationType string
+
+const (
+	AggSum AggregationType = "Sum"
+	// AggMean, AggStd, AggVar and AggMedian are calculated in float64 and
+	// always produce a Float64 column.  Decimal columns are converted to
+	// float64 first, so unlike AggSum their results are not exact
+	AggMean   AggregationType = "Mean"
+	AggMin    AggregationType = "Min"
+	AggMax    AggregationType = "Max"
+	AggCount  AggregationType = "Count"
+	AggFirst  AggregationType = "First"
+	AggLast   AggregationType = "Last"
+	AggStd    AggregationType = "Std"
+	AggVar    AggregationType = "Var"
+	AggMedian AggregationType = "Median"
+	AggCustom AggregationType = "Custom"
+)
+
+// Aggregation describes one column of the dataframe produced by
+// GroupedDataframe.Agg.  OutputName defaults to ColumnName.  Func is only
+// used by AggCustom and receives the non null values of the group
+type Aggregation struct {
+	ColumnName string
+	Type       AggregationType
+	OutputName string
+	Func       func(values []float64) float64
+}
+
+// GroupedDataframe holds the rows of a dataframe split into groups that
+// share the same values in the key columns.  Groups are kept in the order
+// their first row appears in the dataframe
+type GroupedDataframe struct {
+	df     Dataframe
+	keys   []string
+	groups [][]int
+}
+
+// GroupBy splits the rows of the dataframe into groups that share the
+// same values in the named columns.  Nulls in a key column form their
+// own group
+func (d Dataframe) GroupBy(columns ...string) (*GroupedDataframe, error) {
+	if _, err := d.columnsOrAll(columns); err != nil {
+		return nil, err
+	}
+	g := GroupedDataframe{df: d, keys: columns}
+	if len(columns) == 1 && d.columnType(columns[0]) == Categorical {
+		g.groups = d.categoricalColumn(columns[0]).groupByCode()
+		return &g, nil
+	}
+	groupIndex := make(map[string]int)
+	for ndx := 0; ndx < d.numberRows; ndx++ {
+		key := d.rowKey(columns, ndx)
+		groupNdx, ok := groupIndex[key]
+		if !ok {
+			groupNdx = len(g.groups)
+			groupIndex[key] = groupNdx
+			g.groups = append(g.groups, []int{})
+		}
+		g.groups[groupNdx] = append(g.groups[groupNdx], ndx)
+	}
+	return &g, nil
+}
+
+// Length will return the number of groups
+func (g GroupedDataframe) Length() int {
+	return len(g.groups)
+}
+
+// Groups will return a dataframe for each group, in order
+func (g GroupedDataframe) Groups() ([]*Dataframe, error) {
+	var frames []*Dataframe
+	for _, rows := range g.groups {
+		df, err := g.df.Take(rows)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, df)
+	}
+	return frames, nil
+}
+
+// Agg will return a pointer to a new dataframe with one row per group.
+// The key columns come first, followed by one column per aggregation.
+// Nulls are skipped by every aggregation.  Count, Sum, Min, Max, First
+// and Last keep the type of the column (Count is always int), while
+// Mean, Std, Var, Median and custom aggregations produce float64 columns
+func (g GroupedDataframe) Agg(aggregations ...Aggregation) (*Dataframe, error) {
+	firstRows := make([]int, 0, len(g.groups))
+	for _, rows := range g.groups {
+		firstRows = append(firstRows, rows[0])
+	}
+	keys, err := g.df.Select(g.keys...)
+	if err != nil {
+		return nil, err
+	}
+	df, err := keys.Take(firstRows)
+	if err != nil {
+		return nil, err
+	}
+	for _, aggregation := range aggregations {
+		err := g.aggregate(df, aggregation)
+		if err != nil {
+			return nil, fmt.Errorf("unable to aggregate column %s with %s: %w", aggregation.ColumnName, aggregation.Type, err)
+		}
+	}
+	return df, nil
+}
+
+// Sum adds up the named columns, or every numeric column that is not a
+// key if none are named
+func (g GroupedDataframe) Sum(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggSum, columns, true)
+}
+
+// Mean averages the named columns, or every numeric column that is not
+// a key if none are named.  Decimal columns are averaged as float64
+func (g GroupedDataframe) Mean(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggMean, columns, true)
+}
+
+// Min finds the smallest value of the named columns, or every column
+// that is not a key if none are named
+func (g GroupedDataframe) Min(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggMin, columns, false)
+}
+
+// Max finds the largest value of the named columns, or every column
+// that is not a key if none are named
+func (g GroupedDataframe) Max(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggMax, columns, false)
+}
+
+// Count counts the non null values of the named columns, or every
+// column that is not a key if none are named
+func (g GroupedDataframe) Count(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggCount, columns, false)
+}
+
+// First takes the first non null value of the named columns, or every
+// column that is not a key if none are named
+func (g GroupedDataframe) First(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggFirst, columns, false)
+}
+
+// Last takes the last non null value of the named columns, or every
+// column that is not a key if none are named
+func (g GroupedDataframe) Last(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggLast, columns, false)
+}
+
+// Std calculates the sample standard deviation of the named columns, or
+// every numeric column that is not a key if none are named
+func (g GroupedDataframe) Std(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggStd, columns, true)
+}
+
+// Var calculates the sample variance of the named columns, or every
+// numeric column that is not a key if none are named
+func (g GroupedDataframe) Var(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggVar, columns, true)
+}
+
+// Median finds the median of the named columns, or every numeric column
+// that is not a key if none are named
+func (g GroupedDataframe) Median(columns ...string) (*Dataframe, error) {
+	return g.aggregateColumns(AggMedian, columns, true)
+}
+
+// Apply reduces each group of the named column with a custom function,
+// storing the result in a float64 column called outputName
+func (g GroupedDataframe) Apply(columnName, outputName string, fn func(values []float64) float64) (*Dataframe, error) {
+	return g.Agg(Aggregation{ColumnName: columnName, Type: AggCustom, OutputName: outputName, Func: fn})
+}
+
+func (g GroupedDataframe) aggregateColumns(aggType AggregationType, columns []string, numericOnly bool) (*Dataframe, error) {
+	if len(columns) == 0 {
+		for _, series := range g.df.columns {
+			if slices.Contains(g.keys, series.Name()) {
+				continue
+			}
+			if numericOnly && !isNumericKind(series.Type()) {
+				continue
+			}
+			columns = append(columns, series.Name())
+		}
+	}
+	var aggregations []Aggregation
+	for _, columnName := range columns {
+		aggregations = append(aggregations, Aggregation{ColumnName: columnName, Type: aggType})
+	}
+	return g.Agg(aggregations...)
+}
+
+func (g GroupedDataframe) aggregate(df *Dataframe, aggregation Aggregation) error {
+	series, err := g.df.GetSeries(aggregation.ColumnName)
+	if err != nil {
+		return err
+	}
+	outputName := aggregation.OutputName
+	if outputName == "" {
+		outputName = aggregation.ColumnName
+	}
+	if aggregation.Type == AggCustom && aggregation.Func == nil {
+		return fmt.Errorf("custom aggregation requires a function")
+	}
+	numeric, isNumeric := series.(numericSeries)
+	var result Series
+	switch aggregation.Type {
+	case AggCount:
+		return df.AddIntColumn(*countGroups(series, g.groups, outputName))
+	case AggFirst, AggLast, AggMin, AggMax:
+		result, err = pickGroups(series, g.groups, aggregation.Type)
+		if err != nil {
+			return err
+		}
+		result = result.Rename(outputName)
+	case AggSum:
+		var ok bool
+		if isNumeric {
+			result, ok, err = numeric.sumGroups(g.groups, outputName)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return WrongColumnTypeError{aggregation.ColumnName, reflect.Float64, series.Type()}
+		}
+	case AggMean, AggStd, AggVar, AggMedian, AggCustom:
+		var col *Column[float64]
+		var ok bool
+		if isNumeric {
+			col, ok = numeric.float64Column()
+		}
+		if !ok {
+			return WrongColumnTypeError{aggregation.ColumnName, reflect.Float64, series.Type()}
+		}
+		return df.AddFloatColumn(*statGroups(*col, g.groups, aggregation, outputName))
+	default:
+		return fmt.Errorf("aggregation of type %s not supported", aggregation.Type)
+	}
+	return df.AddSeries(result)
+}
+
+// rowKey builds a string that is equal for rows holding equal values in
+// the named columns.  Each value is length prefixed so that values
+// containing the separator cannot collide
+func (d Dataframe) rowKey(columns []string, ndx int) string {
+	var builder strings.Builder
+	for _, columnName := range columns {
+		if d.isNullAt(columnName, ndx) {
+			builder.WriteString("n;")
+			continue
+		}
+		key := d.keyAt(columnName, ndx)
+		fmt.Fprintf(&builder, "%d:%s;", len(key), key)
+	}
+	return builder.String()
+}
+
+func (d Dataframe) keyAt(columnName string, ndx int) string {
+	return keyOf(d.series(columnName), ndx)
+}
+
+// countGroups counts the non null values of each group
+func countGroups(series Series, groups [][]int, outputName string) *Column[int] {
+	counts := make([]int, 0, len(groups))
+	for _, rows := range groups {
+		count := 0
+		for _, ndx := range rows {
+			if !series.IsNull(ndx) {
+				count++
+			}
+		}
+		counts = append(counts, count)
+	}
+	col, _ := NewColumn(outputName, counts)
+	return col
+}
+
+// pickGroups takes one row from each group: the first or last value
+// that is not null, or the smallest or largest for series that
+// implement RowComparer.  Groups without any values are null
+func pickGroups(series Series, groups [][]int, aggType AggregationType) (Series, error) {
+	comparer, ok := series.(RowComparer)
+	if !ok && (aggType == AggMin || aggType == AggMax) {
+		return nil, UnsupportedType{series.Type()}
+	}
+	indices := make([]int, 0, len(groups))
+	for _, rows := range groups {
+		picked := -1
+		for _, ndx := range rows {
+			if series.IsNull(ndx) {
+				continue
+			}
+			switch {
+			case picked == -1, aggType == AggLast:
+				picked = ndx
+			case aggType == AggMin && comparer.CompareRows(ndx, picked) < 0:
+				picked = ndx
+			case aggType == AggMax && comparer.CompareRows(ndx, picked) > 0:
+				picked = ndx
+			}
+			if aggType == AggFirst {
+				break
+			}
+		}
+		indices = append(indices, picked)
+	}
+	return series.Take(indices)
+}
+
+// sumGroups adds up each group of a numeric column.  Groups without any
+// values sum to zero
+func sumGroups[T Columnable](col Column[T], groups [][]int, outputName string) *Column[T] {
+	result := &Column[T]{ColumnName: outputName, ColumnType: col.ColumnType}
+	for _, rows := range groups {
+		var sum T
+		for _, ndx := range rows {
+			if col.IsValid(ndx) {
+				sum += col.data[ndx]
+			}
+		}
+		result.AppendValue(sum)
+	}
+	return result
+}
+
+// sumDecimalGroups adds up each group of a decimal column exactly,
+// returning an error instead of a wrong total if a sum overflows
+func sumDecimalGroups(col DecimalColumn, groups [][]int, outputName string) (*DecimalColumn, error) {
+	result, err := NewDecimalColumn(outputName, col.Scale, []DecimalValue{})
+	if err != nil {
+		return nil, err
+	}
+	for _, rows := range groups {
+		sum := DecimalValue{0, col.Scale}
+		for _, ndx := range rows {
+			if col.IsNull(ndx) {
+				continue
+			}
+			sum, err = sum.Add(DecimalValue{col.data[ndx], col.Scale})
+			if err != nil {
+				return nil, fmt.Errorf("unable to sum column %s: %w", col.ColumnName, err)
+			}
+		}
+		result.AppendValue(sum.Unscaled)
+	}
+	return result, nil
+}
+
+// statGroups reduces each group of a numeric column to a float64.  Groups
+// without enough values for the statistic are null
+func statGroups[T Numeric](col Column[T], groups [][]int, aggregation Aggregation, outputName string) *Column[float64] {
+	result := &Column[float64]{ColumnName: outputName, ColumnType: reflect.Float64}
+	for _, rows := range groups {
+		values := make([]float64, 0, len(rows))
+		for _, ndx := range rows {
+			if col.IsValid(ndx) {
+				values = append(values, float64(col.data[ndx]))
+			}
+		}
+		value, ok := calculateStatistic(aggregation, values)
+		if ok {
+			result.AppendValue(value)
+		} else {
+			result.AppendNull()
+		}
+	}
+	return result
+}
+
+func calculateStatistic(aggregation Aggregation, values []float64) (float64, bool) {
+	if aggregation.Type == AggCustom {
+		return aggregation.Func(values), true
+	}
+	if len(values) == 0 {
+		return 0, false
+	}
+	switch aggregation.Type {
+	case AggMean:
+		return mean(values), true
+	case AggMedian:
+		sorted := slices.Clone(values)
+		slices.Sort(sorted)
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 1 {
+			return sorted[middle], true
+		}
+		return (sorted[middle-1] + sorted[middle]) / 2, true
+	}
+	if len(values) < 2 {
+		return 0, false
+	}
+	average := mean(values)
+	sumSquares := 0.0
+	for _, v := range values {
+		sumSquares += (v - average) * (v - average)
+	}
+	variance := sumSquares / float64(len(values)-1)
+	if aggregation.Type == AggStd {
+		return math.Sqrt(variance), true
+	}
+	return variance, true
+}
+
+func mean(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total / float64(len(values))
+}
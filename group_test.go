@@ -0,0 +1,101 @@
+package dataframe
+
+import (
+	"math"
+	"testing"
+)
+
+var groupTestColumns = []testColumn{
+	{"Ticker", []string{"AAA", "BBB", "AAA", "BBB", "AAA", "CCC"}, nil},
+	{"Close", []float64{1, 10, 2, 20, 6, 0}, []bool{true, true, true, true, true, false}},
+	{"Volume", []int{100, 200, 300, 400, 500, 600}, nil},
+}
+
+func TestGroupByAggregations(t *testing.T) {
+	df := buildTestDataframe(t, groupTestColumns...)
+	grouped, err := df.GroupBy("Ticker")
+	if err != nil {
+		t.Fatalf("unable to group dataframe: %s", err)
+		return
+	}
+	if grouped.Length() != 3 {
+		t.Fatalf("expected 3 groups, but found %d", grouped.Length())
+		return
+	}
+	result, err := grouped.Agg(
+		Aggregation{ColumnName: "Close", Type: AggMean, OutputName: "MeanClose"},
+		Aggregation{ColumnName: "Close", Type: AggCount, OutputName: "CloseCount"},
+		Aggregation{ColumnName: "Volume", Type: AggSum},
+		Aggregation{ColumnName: "Volume", Type: AggMax, OutputName: "MaxVolume"},
+		Aggregation{ColumnName: "Close", Type: AggMedian, OutputName: "MedianClose"},
+		Aggregation{ColumnName: "Close", Type: AggStd, OutputName: "StdClose"},
+		Aggregation{ColumnName: "Ticker", Type: AggLast, OutputName: "LastTicker"},
+	)
+	if err != nil {
+		t.Fatalf("unable to aggregate groups: %s", err)
+		return
+	}
+	if result.Length() != 3 {
+		t.Errorf("expected 3 rows in the aggregated dataframe, but found %d", result.Length())
+	}
+	testStringHelper(t, "Ticker", 0, "AAA", result)
+	testStringHelper(t, "Ticker", 2, "CCC", result)
+	testFloatHelper(t, "MeanClose", 0, 3, result)
+	testFloatHelper(t, "MeanClose", 1, 15, result)
+	testIntHelper(t, "CloseCount", 2, 0, result)
+	testIntHelper(t, "Volume", 0, 900, result)
+	testIntHelper(t, "MaxVolume", 1, 400, result)
+	testFloatHelper(t, "MedianClose", 0, 2, result)
+	testStringHelper(t, "LastTicker", 1, "BBB", result)
+	std, err := result.GetFloatValue("StdClose", 0)
+	if err != nil || math.Abs(std-math.Sqrt(7)) > 1e-9 {
+		t.Errorf("expected a standard deviation of %f, but found %f (%v)", math.Sqrt(7), std, err)
+	}
+	for _, columnName := range []string{"MeanClose", "MedianClose", "StdClose"} {
+		if isNull, _ := result.IsNull(columnName, 2); !isNull {
+			t.Errorf("expected %s to be null for a group without values", columnName)
+		}
+	}
+}
+
+func TestGroupByConvenienceMethods(t *testing.T) {
+	df := buildTestDataframe(t, groupTestColumns...)
+	grouped, err := df.GroupBy("Ticker")
+	if err != nil {
+		t.Fatalf("unable to group dataframe: %s", err)
+		return
+	}
+	sums, err := grouped.Sum()
+	if err != nil {
+		t.Fatalf("unable to sum groups: %s", err)
+		return
+	}
+	if len(sums.Names()) != 3 {
+		t.Errorf("expected Sum to cover the key and both numeric columns, but found %v", sums.Names())
+	}
+	testFloatHelper(t, "Close", 1, 30, sums)
+	firsts, err := grouped.First("Volume")
+	if err != nil {
+		t.Fatalf("unable to take the first of each group: %s", err)
+		return
+	}
+	testIntHelper(t, "Volume", 0, 100, firsts)
+	ranges, err := grouped.Apply("Volume", "Range", func(values []float64) float64 {
+		low, high := values[0], values[0]
+		for _, v := range values {
+			low, high = math.Min(low, v), math.Max(high, v)
+		}
+		return high - low
+	})
+	if err != nil {
+		t.Fatalf("unable to apply custom aggregation: %s", err)
+		return
+	}
+	testFloatHelper(t, "Range", 0, 400, ranges)
+	if _, err := grouped.Sum("Ticker"); err == nil {
+		t.Errorf("expected an error when summing a string column")
+	}
+	if _, err := df.GroupBy("Missing"); err == nil {
+		t.Errorf("expected an error when grouping by a missing column")
+	}
+}
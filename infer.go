@@ -0,0 +1,222 @@
+package dataframe
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultInferRows is the number of rows InferSchema samples when it is
+// given a sample size of 0 or less
+const DefaultInferRows = 1000
+
+// InferSchema samples the first sampleRows rows of a CSV file and picks a
+// type for every column.  A column is a Bool if every value is true,
+// false, yes or no in any case, an Int if every value fits in 32 bits, an
+// Int64 if every value is a 64 bit integer, a Float64 if every value is a
+// number, a Date or Timestamp if every value is an ISO 8601 date or an
+// RFC 3339 timestamp and a String otherwise.  Null values, as configured
+// in opts, are ignored, and a column that is entirely null is a String.
+// When opts.HasHeader is set the header provides the column names,
+// otherwise the columns are named column_0, column_1 and so on
+func InferSchema(filename string, opts CSVOptions, sampleRows int) (*Schema, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file %s: %w", filename, err)
+	}
+	defer f.Close()
+	decompressed, err := newDecompressReader(f, opts.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress file %s: %w", filename, err)
+	}
+	defer decompressed.Close()
+	schema, err := inferSchema(opts.newReader(decompressed), opts, sampleRows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to infer schema for %s: %w", filename, err)
+	}
+	return schema, nil
+}
+
+// FromCSVInfer creates a Dataframe from CSV without a hand built schema,
+// using InferSchema with DefaultInferRows to decide the column types
+func FromCSVInfer(filename string, opts CSVOptions) (*Dataframe, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file %s: %w", filename, err)
+	}
+	defer f.Close()
+	df, err := ReadCSVInfer(f, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read records from %s: %w", filename, err)
+	}
+	return df, nil
+}
+
+// ReadCSVInfer works like FromCSVInfer, but reads the CSV from r.  The
+// rows sampled to infer the schema are buffered and replayed, so r is
+// only read once
+func ReadCSVInfer(r io.Reader, opts CSVOptions) (*Dataframe, error) {
+	// The raw bytes are buffered, so compressed input is decompressed
+	// once for sampling and again from the start when it is replayed
+	var sampled bytes.Buffer
+	decompressed, err := newDecompressReader(io.TeeReader(r, &sampled), opts.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress input: %w", err)
+	}
+	schema, err := inferSchema(opts.newReader(decompressed), opts, DefaultInferRows)
+	decompressed.Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to infer schema: %w", err)
+	}
+	return ReadCSV(io.MultiReader(&sampled, r), *schema, opts)
+}
+
+// ReadCSVInferFS works like FromCSVInfer, but reads the named file from
+// fsys
+func ReadCSVInferFS(fsys fs.FS, name string, opts CSVOptions) (*Dataframe, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file %s: %w", name, err)
+	}
+	defer f.Close()
+	df, err := ReadCSVInfer(f, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read records from %s: %w", name, err)
+	}
+	return df, nil
+}
+
+func inferSchema(reader *csv.Reader, opts CSVOptions, sampleRows int) (*Schema, error) {
+	if sampleRows <= 0 {
+		sampleRows = DefaultInferRows
+	}
+	if opts.MaxRows > 0 && opts.MaxRows < sampleRows {
+		sampleRows = opts.MaxRows
+	}
+	header, err := opts.readPreamble(reader)
+	if err != nil {
+		return nil, err
+	}
+	if opts.HasHeader && header == nil {
+		return nil, fmt.Errorf("unable to read header: file is empty")
+	}
+	fields := len(header)
+	var inferrers []*typeInferrer
+	for rowNumber := 0; rowNumber < sampleRows; rowNumber++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read row %d: %w", rowNumber, err)
+		}
+		err = checkFieldCount(&fields, record, rowNumber)
+		if err != nil && opts.lenient() {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for len(inferrers) < len(record) {
+			inferrers = append(inferrers, newTypeInferrer())
+		}
+		for ndx, value := range record {
+			if !opts.isNull(value) {
+				inferrers[ndx].observe(value)
+			}
+		}
+	}
+	for len(inferrers) < len(header) {
+		inferrers = append(inferrers, newTypeInferrer())
+	}
+	defs := make([]SchemaDef, 0, len(inferrers))
+	for ndx, inferrer := range inferrers {
+		columnName := fmt.Sprintf("column_%d", ndx)
+		if ndx < len(header) {
+			columnName = header[ndx]
+		}
+		defs = append(defs, SchemaDef{ColumnName: columnName, ColumnType: inferrer.kind()})
+	}
+	return SchemaFromDefs(defs)
+}
+
+// typeInferrer narrows down the type of a column as values are observed
+type typeInferrer struct {
+	seen        bool
+	isBool      bool
+	isInt       bool
+	isInt64     bool
+	isFloat     bool
+	isDate      bool
+	isTimestamp bool
+}
+
+func newTypeInferrer() *typeInferrer {
+	return &typeInferrer{isBool: true, isInt: true, isInt64: true, isFloat: true, isDate: true, isTimestamp: true}
+}
+
+func (t *typeInferrer) observe(value string) {
+	t.seen = true
+	if t.isBool {
+		// 1 and 0 parse as bools too, but a column of them is inferred
+		// as an integer column
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "true", "false", "yes", "no":
+		default:
+			t.isBool = false
+		}
+	}
+	if t.isInt64 {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			t.isInt, t.isInt64 = false, false
+		} else if parsed > math.MaxInt32 || parsed < math.MinInt32 {
+			t.isInt = false
+		}
+	}
+	if t.isFloat && !t.isInt64 {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			t.isFloat = false
+		}
+	}
+	if t.isDate {
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			t.isDate = false
+		}
+	}
+	if t.isTimestamp {
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			t.isTimestamp = false
+		}
+	}
+}
+
+func (t typeInferrer) kind() reflect.Kind {
+	switch {
+	case !t.seen:
+		return reflect.String
+	case t.isBool:
+		return reflect.Bool
+	case t.isInt:
+		return reflect.Int
+	case t.isInt64:
+		return reflect.Int64
+	case t.isFloat:
+		return reflect.Float64
+	case t.isDate:
+		return Date
+	case t.isTimestamp:
+		return Timestamp
+	default:
+		return reflect.String
+	}
+}
@@ -1,55 +1,743 @@
 package dataframe
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 )
 
+// DefaultNullValues are the tokens treated as null when CSVOptions
+// does not specify any
+var DefaultNullValues = []string{""}
+
+// DefaultBatchSize is the number of rows each worker parses at a time
+// when CSVOptions does not specify BatchSize
+const DefaultBatchSize = 10000
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ErrorPolicy decides what happens to a row with a cell that cannot be
+// parsed, or with the wrong number of fields
+type ErrorPolicy string
+
+const (
+	// ErrorFail stops reading and returns the error.  It is the default
+	ErrorFail ErrorPolicy = "Fail"
+	// ErrorSkip drops the row and carries on
+	ErrorSkip ErrorPolicy = "Skip"
+	// ErrorNull stores a null for every cell that cannot be parsed and
+	// keeps the row.  Rows with the wrong number of fields are dropped
+	ErrorNull ErrorPolicy = "Null"
+	// ErrorCollect drops the row and reports it as a RejectedRow
+	ErrorCollect ErrorPolicy = "Collect"
+)
+
+// RejectedRow describes a row that was dropped under ErrorCollect
+type RejectedRow struct {
+	// Row is the number of the data row, counting from zero after the
+	// header and any skipped rows
+	Row int
+	// Column is the schema column that could not be parsed.  It is empty
+	// when the row had the wrong number of fields
+	Column string
+	// Value is the raw cell, or the whole raw row when Column is empty
+	Value string
+	Err   error
+}
+
+// CSVOptions controls how a CSV file is read into a Dataframe
+type CSVOptions struct {
+	// HasHeader skips the first row of the file, or the row at HeaderRow
+	HasHeader bool
+	// HeaderRow is the position of the header among the rows left after
+	// SkipRows when HasHeader is set.  Rows before the header are discarded
+	HeaderRow int
+	// SkipRows discards this many rows from the start of the file, before
+	// looking for the header or data.  Skipped rows may have any number of
+	// fields
+	SkipRows int
+	// MaxRows stops reading once this many data rows are in the dataframe.
+	// Rows rejected under ErrorSkip or ErrorCollect do not count towards
+	// it.  Zero or less reads every row
+	MaxRows int
+	// Delimiter separates the fields of a row, such as '\t' for TSV, '|' or
+	// ';'.  It defaults to a comma
+	Delimiter rune
+	// Comment marks lines that are ignored when it starts the line.  No
+	// lines are ignored when it is zero
+	Comment rune
+	// LazyQuotes allows a quote to appear in an unquoted field and a non
+	// doubled quote to appear in a quoted field
+	LazyQuotes bool
+	// TrimLeadingSpace ignores leading white space in a field
+	TrimLeadingSpace bool
+	// StripBOM removes a UTF-8 byte order mark from the start of the file
+	StripBOM bool
+	// NullValues lists the cell values that are stored as null, such as
+	// "", "NA", "null" or "NaN".  When nil, DefaultNullValues is used.
+	// Use an empty, non-nil slice to disable null detection entirely
+	NullValues []string
+	// MatchHeader maps CSV columns to schema columns by name, using the
+	// header row, instead of by position.  Names are compared ignoring
+	// case and surrounding spaces, CSV columns that are not in the schema
+	// are ignored and a schema column missing from the header returns a
+	// MissingHeaderError.  Requires HasHeader
+	MatchHeader bool
+	// Aliases lists other header names that are accepted for a schema
+	// column when MatchHeader is set, keyed by the schema column name
+	Aliases map[string][]string
+	// Columns limits the dataframe to the named schema columns.  Cells
+	// in any other column are skipped without being parsed
+	Columns []string
+	// ColumnIndices limits the dataframe to the schema columns at these
+	// positions.  It can be combined with Columns, and the dataframe keeps
+	// the selected columns in schema order
+	ColumnIndices []int
+	// Workers parses rows on this many goroutines.  Records are still read
+	// in order by a single goroutine, split into batches and parsed into
+	// separate columns that are stitched back together in file order.  Zero
+	// or one parses every row on the calling goroutine
+	Workers int
+	// BatchSize is the number of rows handed to a worker at a time when
+	// Workers is more than one.  It defaults to DefaultBatchSize
+	BatchSize int
+	// OnError decides what happens to bad rows.  It defaults to ErrorFail
+	OnError ErrorPolicy
+	// Compression is the format of the input.  When NoCompression, it is
+	// detected from the leading bytes.  Use Uncompressed to read the input
+	// as plain CSV without detection
+	Compression Compression
+}
+
+// lenient reports if bad rows are handled instead of returned as errors
+func (o CSVOptions) lenient() bool {
+	return o.OnError != "" && o.OnError != ErrorFail
+}
+
+// projectSchema returns the part of the schema selected by Columns and
+// ColumnIndices along with the set of selected names, which is nil when
+// every column is selected
+func (o CSVOptions) projectSchema(schema Schema) (*Schema, map[string]bool, error) {
+	if len(o.Columns) == 0 && len(o.ColumnIndices) == 0 {
+		return &schema, nil, nil
+	}
+	selected := make(map[string]bool)
+	for _, columnName := range o.Columns {
+		if !slices.Contains(schema.Names(), columnName) {
+			return nil, nil, MissingColumnError{ColumnName: columnName}
+		}
+		selected[columnName] = true
+	}
+	for _, ndx := range o.ColumnIndices {
+		columnName, err := schema.ColumnFromIndex(ndx)
+		if err != nil {
+			return nil, nil, err
+		}
+		selected[columnName] = true
+	}
+	var defs []SchemaDef
+	for ndx, columnName := range schema.columnOrder {
+		if selected[columnName] {
+			defs = append(defs, SchemaDef{ColumnName: columnName, ColumnType: schema.columnType[ndx], Scale: schema.scales[columnName]})
+		}
+	}
+	projected, err := SchemaFromDefs(defs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for columnName, format := range schema.timeFormats {
+		if selected[columnName] {
+			projected.SetTimeFormat(columnName, format)
+		}
+	}
+	return projected, selected, nil
+}
+
+// newReader wraps r in a csv.Reader configured by the dialect options.
+// Field counts are checked by the caller, so that rows skipped by SkipRows
+// can have any number of fields
+func (o CSVOptions) newReader(r io.Reader) *csv.Reader {
+	if o.StripBOM {
+		buffered := bufio.NewReader(r)
+		bom, err := buffered.Peek(len(utf8BOM))
+		if err == nil && bytes.Equal(bom, utf8BOM) {
+			buffered.Discard(len(utf8BOM))
+		}
+		r = buffered
+	}
+	reader := csv.NewReader(r)
+	if o.Delimiter != 0 {
+		reader.Comma = o.Delimiter
+	}
+	reader.Comment = o.Comment
+	reader.LazyQuotes = o.LazyQuotes
+	reader.TrimLeadingSpace = o.TrimLeadingSpace
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	return reader
+}
+
+// readPreamble discards the rows before the data and returns the header,
+// which is nil when HasHeader is not set or the file ends early
+func (o CSVOptions) readPreamble(reader *csv.Reader) ([]string, error) {
+	skip := o.SkipRows
+	if o.HasHeader {
+		skip += o.HeaderRow
+	}
+	for ndx := 0; ndx < skip; ndx++ {
+		_, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to skip row %d: %w", ndx, err)
+		}
+	}
+	if !o.HasHeader {
+		return nil, nil
+	}
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read header: %w", err)
+	}
+	return slices.Clone(header), nil
+}
+
+// checkFieldCount makes sure every row has as many fields as the first
+// row read after the preamble
+func checkFieldCount(fields *int, record []string, rowNumber int) error {
+	if *fields == 0 {
+		*fields = len(record)
+	}
+	if len(record) != *fields {
+		return fmt.Errorf("row %d has %d fields, but expected %d: %w", rowNumber, len(record), *fields, csv.ErrFieldCount)
+	}
+	return nil
+}
+
+func (o CSVOptions) isNull(value string) bool {
+	nullValues := o.NullValues
+	if nullValues == nil {
+		nullValues = DefaultNullValues
+	}
+	return slices.Contains(nullValues, value)
+}
+
 // Creates a Dataframe from CSV.  Allows the specification of a header.  If it
-// has a header, it will skip the first row.  Schema is required (although this
-// will hopefully change in the future)
+// has a header, it will skip the first row.  Schema is required, use FromCSVInfer
+// to have the schema inferred from the file instead
 func FromCSV(filename string, schema Schema, hasHeader bool) (*Dataframe, error) {
+	return FromCSVWithOptions(filename, schema, CSVOptions{HasHeader: hasHeader})
+}
+
+// FromCSVWithOptions creates a Dataframe from CSV using the provided
+// options to control how the file is read.  Records are parsed one at a
+// time as they are read, so the raw file is never held in memory
+func FromCSVWithOptions(filename string, schema Schema, opts CSVOptions) (*Dataframe, error) {
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("unable to open file %s: %w", filename, err)
 	}
-	r := csv.NewReader(f)
-	records, err := r.ReadAll()
+	defer f.Close()
+	df, err := ReadCSV(f, schema, opts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read records from %s: %w", filename, err)
 	}
-	df, err := parseCSVRecords(records, schema, hasHeader)
+	return df, nil
+}
+
+// ReadCSV creates a Dataframe from CSV read from r, such as an HTTP body
+// or a bytes.Buffer.  Input compressed with gzip, zstd, bzip2 or xz is
+// detected from its leading bytes and decompressed on the fly
+func ReadCSV(r io.Reader, schema Schema, opts CSVOptions) (*Dataframe, error) {
+	chunks, err := NewCSVChunkReader(r, schema, opts, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer chunks.Close()
+	return chunks.Next()
+}
+
+// FromCSVWithRejects creates a Dataframe from CSV like FromCSVWithOptions,
+// also returning the rows dropped when opts.OnError is ErrorCollect
+func FromCSVWithRejects(filename string, schema Schema, opts CSVOptions) (*Dataframe, []RejectedRow, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open file %s: %w", filename, err)
+	}
+	defer f.Close()
+	df, rejects, err := ReadCSVWithRejects(f, schema, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read records from %s: %w", filename, err)
+	}
+	return df, rejects, nil
+}
+
+// ReadCSVWithRejects creates a Dataframe from CSV like ReadCSV, also
+// returning the rows dropped when opts.OnError is ErrorCollect
+func ReadCSVWithRejects(r io.Reader, schema Schema, opts CSVOptions) (*Dataframe, []RejectedRow, error) {
+	chunks, err := NewCSVChunkReader(r, schema, opts, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer chunks.Close()
+	df, err := chunks.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	return df, chunks.Rejects(), nil
+}
+
+// ReadCSVFS creates a Dataframe from the named CSV file in fsys, such as
+// an embed.FS holding test fixtures
+func ReadCSVFS(fsys fs.FS, name string, schema Schema, opts CSVOptions) (*Dataframe, error) {
+	f, err := fsys.Open(name)
 	if err != nil {
-		return nil, fmt.Errorf("error during csv record parsing: %w", err)
+		return nil, fmt.Errorf("unable to open file %s: %w", name, err)
+	}
+	defer f.Close()
+	df, err := ReadCSV(f, schema, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read records from %s: %w", name, err)
 	}
 	return df, nil
 }
 
-func parseCSVRecords(records [][]string, schema Schema, hasHeader bool) (*Dataframe, error) {
-	df, err := schema.BuildDF()
+// CSVChunkReader reads a CSV into a series of dataframes holding a fixed
+// number of rows each, so that files larger than memory can be processed
+// a piece at a time
+type CSVChunkReader struct {
+	reader    *csv.Reader
+	closers   []io.Closer
+	schema    Schema
+	output    Schema
+	selected  map[string]bool
+	opts      CSVOptions
+	chunkSize int
+	columnMap []string
+	fields    int
+	rowNumber int
+	accepted  int
+	chunks    int
+	rejects   []RejectedRow
+	started   bool
+	done      bool
+}
+
+// NewCSVChunkReader creates a CSVChunkReader that reads from r, which is
+// decompressed on the fly if it is compressed.  Each call to Next returns
+// up to chunkSize rows.  A chunkSize of 0 or less reads the whole input in
+// a single chunk.  Close should be called once reading is finished
+func NewCSVChunkReader(r io.Reader, schema Schema, opts CSVOptions, chunkSize int) (*CSVChunkReader, error) {
+	if _, err := schema.BuildDF(); err != nil {
+		return nil, err
+	}
+	if opts.MatchHeader && !opts.HasHeader {
+		return nil, fmt.Errorf("matching columns by header requires a header")
+	}
+	output, selected, err := opts.projectSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to select columns: %w", err)
+	}
+	decompressed, err := newDecompressReader(r, opts.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress input: %w", err)
+	}
+	return &CSVChunkReader{
+		reader:    opts.newReader(decompressed),
+		closers:   []io.Closer{decompressed},
+		schema:    schema,
+		output:    *output,
+		selected:  selected,
+		opts:      opts,
+		chunkSize: chunkSize,
+	}, nil
+}
+
+// OpenCSVChunkReader opens the named file and creates a CSVChunkReader
+// for it.  Close must be called once reading is finished
+func OpenCSVChunkReader(filename string, schema Schema, opts CSVOptions, chunkSize int) (*CSVChunkReader, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file %s: %w", filename, err)
+	}
+	chunks, err := NewCSVChunkReader(f, schema, opts, chunkSize)
 	if err != nil {
+		f.Close()
 		return nil, err
 	}
-	recordSlice := records
-	if hasHeader {
-		recordSlice = records[1:]
+	chunks.closers = append(chunks.closers, f)
+	return chunks, nil
+}
+
+// Next returns a dataframe holding the next chunk of rows.  Once every
+// row has been read, it returns io.EOF.  A file without any rows still
+// produces a single empty dataframe before io.EOF
+func (c *CSVChunkReader) Next() (*Dataframe, error) {
+	if c.done {
+		return nil, io.EOF
 	}
-	for rowNumber, record := range recordSlice {
-		for ndx, value := range record {
-			columnName, err := schema.ColumnFromIndex(ndx)
+	if !c.started {
+		c.started = true
+		header, err := c.opts.readPreamble(c.reader)
+		if err != nil {
+			return nil, err
+		}
+		c.fields = len(header)
+		if c.opts.MatchHeader {
+			c.columnMap, err = mapHeader(header, c.output, c.opts.Aliases)
 			if err != nil {
 				return nil, err
 			}
-			err = df.ParseValue(columnName, value)
-			if err != nil {
-				return nil, fmt.Errorf("unable to parse column %d on row %d: %w", ndx, rowNumber, err)
-			}
 		}
 	}
+	df, err := c.output.BuildDF()
+	if err != nil {
+		return nil, err
+	}
+	var rows int
+	if c.opts.Workers > 1 {
+		rows, err = c.readParallel(df)
+	} else {
+		rows, err = c.readSequential(df)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 && c.chunks > 0 {
+		return nil, io.EOF
+	}
+	c.chunks++
 	err = df.IsValid()
 	if err != nil {
 		return nil, fmt.Errorf("unable to create dataframe as its invalid: %w", err)
 	}
 	return df, nil
 }
+
+// Rejects returns the rows dropped so far under ErrorCollect, in file
+// order
+func (c *CSVChunkReader) Rejects() []RejectedRow {
+	return c.rejects
+}
+
+// Close releases the decompressor, if one was needed, and closes the file
+// opened by OpenCSVChunkReader.  It never closes a reader given to
+// NewCSVChunkReader
+func (c *CSVChunkReader) Close() error {
+	var err error
+	for _, closer := range c.closers {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	c.closers = nil
+	return err
+}
+
+// limit is the number of rows that can still be accepted under MaxRows,
+// or -1 when there is no limit
+func (c *CSVChunkReader) limit() int {
+	if c.opts.MaxRows <= 0 {
+		return -1
+	}
+	return max(c.opts.MaxRows-c.accepted, 0)
+}
+
+// nextRecord reads the next data row and its row number, returning io.EOF
+// once the input or MaxRows is exhausted
+func (c *CSVChunkReader) nextRecord() ([]string, int, error) {
+	for {
+		if c.done || c.limit() == 0 {
+			c.done = true
+			return nil, 0, io.EOF
+		}
+		record, err := c.reader.Read()
+		if errors.Is(err, io.EOF) {
+			c.done = true
+			return nil, 0, io.EOF
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to read row %d: %w", c.rowNumber, err)
+		}
+		rowNumber := c.rowNumber
+		c.rowNumber++
+		err = checkFieldCount(&c.fields, record, rowNumber)
+		if err == nil {
+			return record, rowNumber, nil
+		}
+		if !c.opts.lenient() {
+			return nil, 0, err
+		}
+		c.reject(RejectedRow{rowNumber, "", c.joinRecord(record), err})
+	}
+}
+
+// reject records a dropped row when rejects are being collected
+func (c *CSVChunkReader) reject(row RejectedRow) {
+	if c.opts.OnError == ErrorCollect {
+		c.rejects = append(c.rejects, row)
+	}
+}
+
+func (c *CSVChunkReader) joinRecord(record []string) string {
+	delimiter := c.reader.Comma
+	return strings.Join(record, string(delimiter))
+}
+
+// remaining is the number of rows left in the current chunk, or -1 when
+// chunks are unbounded
+func (c *CSVChunkReader) remaining(rows int) int {
+	if c.chunkSize <= 0 {
+		return -1
+	}
+	return c.chunkSize - rows
+}
+
+func (c *CSVChunkReader) readSequential(df *Dataframe) (int, error) {
+	rows := 0
+	for ; c.remaining(rows) != 0; rows++ {
+		record, rowNumber, err := c.nextRecord()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+		rejected, err := c.parseRecord(df, record, rowNumber)
+		if err != nil {
+			return rows, fmt.Errorf("error during csv record parsing: %w", err)
+		}
+		if rejected != nil {
+			c.reject(*rejected)
+			rows--
+			continue
+		}
+		c.accepted++
+	}
+	return rows, nil
+}
+
+// readParallel reads up to Workers * BatchSize records at a time, parses
+// each batch into its own dataframe on the worker pool and appends the
+// batches to df in file order.  When several rows are bad, the error for
+// the earliest one is returned, matching readSequential
+func (c *CSVChunkReader) readParallel(df *Dataframe) (int, error) {
+	batchSize := c.opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	rows := 0
+	for !c.done && c.remaining(rows) != 0 {
+		wave := c.opts.Workers * batchSize
+		if left := c.remaining(rows); left > 0 && left < wave {
+			wave = left
+		}
+		// Only as many records as MaxRows still allows are read, since
+		// some of them may yet be rejected
+		if left := c.limit(); left >= 0 && left < wave {
+			wave = left
+		}
+		if wave == 0 {
+			c.done = true
+			break
+		}
+		var records [][]string
+		var rowNumbers []int
+		waveRejects := len(c.rejects)
+		var readErr error
+		for len(records) < wave {
+			record, rowNumber, err := c.nextRecord()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				readErr = err
+				break
+			}
+			// The csv.Reader reuses its record, so each one is copied
+			// before the next is read
+			records = append(records, slices.Clone(record))
+			rowNumbers = append(rowNumbers, rowNumber)
+		}
+		batches := (len(records) + batchSize - 1) / batchSize
+		frames := make([]*Dataframe, batches)
+		rejected := make([][]RejectedRow, batches)
+		errs := make([]error, batches)
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for worker := 0; worker < min(c.opts.Workers, batches); worker++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for batch := range jobs {
+					start := batch * batchSize
+					end := min(start+batchSize, len(records))
+					frames[batch], rejected[batch], errs[batch] = c.parseBatch(records[start:end], rowNumbers[start:end])
+				}
+			}()
+		}
+		for batch := 0; batch < batches; batch++ {
+			jobs <- batch
+		}
+		close(jobs)
+		wg.Wait()
+		for batch, frame := range frames {
+			if errs[batch] != nil {
+				return rows, errs[batch]
+			}
+			c.rejects = append(c.rejects, rejected[batch]...)
+			err := df.appendFrame(*frame)
+			if err != nil {
+				return rows, err
+			}
+			rows += frame.Length()
+			c.accepted += frame.Length()
+		}
+		// Rows with the wrong number of fields were rejected while reading,
+		// so the rejects of this wave are put back into file order
+		slices.SortStableFunc(c.rejects[waveRejects:], func(a, b RejectedRow) int {
+			return cmp.Compare(a.Row, b.Row)
+		})
+		if readErr != nil {
+			return rows, readErr
+		}
+	}
+	return rows, nil
+}
+
+// parseBatch parses records into a new dataframe, using rowNumbers to
+// number them in errors and rejects
+func (c *CSVChunkReader) parseBatch(records [][]string, rowNumbers []int) (*Dataframe, []RejectedRow, error) {
+	df, err := c.output.BuildDF()
+	if err != nil {
+		return nil, nil, err
+	}
+	var rejects []RejectedRow
+	for ndx, record := range records {
+		rejected, err := c.parseRecord(df, record, rowNumbers[ndx])
+		if err != nil {
+			return nil, nil, fmt.Errorf("error during csv record parsing: %w", err)
+		}
+		if rejected != nil && c.opts.OnError == ErrorCollect {
+			rejects = append(rejects, *rejected)
+		}
+	}
+	err = df.IsValid()
+	if err != nil {
+		return nil, nil, err
+	}
+	return df, rejects, nil
+}
+
+// parseRecord appends a record to df.  Under ErrorSkip and ErrorCollect,
+// a row with a cell that cannot be parsed is removed again and returned
+// as a RejectedRow instead of an error.  A row with more fields than the
+// schema is returned as a RejectedRow under every policy but ErrorFail,
+// like any other row with the wrong number of fields
+func (c *CSVChunkReader) parseRecord(df *Dataframe, record []string, rowNumber int) (*RejectedRow, error) {
+	if len(record) > 0 {
+		if _, err := c.columnForIndex(len(record) - 1); err != nil {
+			if !c.opts.lenient() {
+				return nil, err
+			}
+			return &RejectedRow{rowNumber, "", c.joinRecord(record), err}, nil
+		}
+	}
+	for ndx, value := range record {
+		columnName, err := c.columnForIndex(ndx)
+		if err != nil {
+			return nil, err
+		}
+		if columnName == "" || (c.selected != nil && !c.selected[columnName]) {
+			continue
+		}
+		if c.opts.isNull(value) {
+			err = df.AppendNull(columnName)
+		} else {
+			err = df.ParseValue(columnName, value)
+		}
+		if err == nil {
+			continue
+		}
+		var parseErr ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Row = rowNumber
+			err = parseErr
+		}
+		switch c.opts.OnError {
+		case ErrorNull:
+			err = df.AppendNull(columnName)
+			if err != nil {
+				return nil, err
+			}
+		case ErrorSkip, ErrorCollect:
+			df.dropPartialRow()
+			return &RejectedRow{rowNumber, columnName, value, err}, nil
+		default:
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// columnForIndex finds the schema column for a CSV column, returning an
+// empty name for CSV columns that are ignored
+func (c *CSVChunkReader) columnForIndex(ndx int) (string, error) {
+	if c.columnMap == nil {
+		return c.schema.ColumnFromIndex(ndx)
+	}
+	if ndx >= len(c.columnMap) {
+		return "", IndexOutOfBounds{"header", ndx, len(c.columnMap)}
+	}
+	return c.columnMap[ndx], nil
+}
+
+// mapHeader matches every schema column to a column of the header,
+// returning the schema column name for each header column or an empty
+// name for header columns that are not in the schema
+func mapHeader(header []string, schema Schema, aliases map[string][]string) ([]string, error) {
+	positions := make(map[string]int)
+	for ndx, name := range header {
+		key := normalizeHeader(name)
+		if _, ok := positions[key]; !ok {
+			positions[key] = ndx
+		}
+	}
+	columnMap := make([]string, len(header))
+	for _, columnName := range schema.Names() {
+		found := false
+		for _, name := range append([]string{columnName}, aliases[columnName]...) {
+			ndx, ok := positions[normalizeHeader(name)]
+			if !ok {
+				continue
+			}
+			if columnMap[ndx] != "" {
+				return nil, fmt.Errorf("header column %s matches both %s and %s", header[ndx], columnMap[ndx], columnName)
+			}
+			columnMap[ndx] = columnName
+			found = true
+			break
+		}
+		if !found {
+			return nil, MissingHeaderError{columnName, header}
+		}
+	}
+	return columnMap, nil
+}
+
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -1,8 +1,11 @@
 package dataframe
 
 import (
+	"bytes"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"reflect"
@@ -10,6 +13,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 const (
@@ -25,18 +29,22 @@ DFRAME,141119,17.71,17.65,17.72,17.63,1639026,337`
 
 var (
 	testFileSchemaDefs = []SchemaDef{
-		{"Symbol", reflect.String},
-		{"Volume", reflect.Int},
-		{"Open", reflect.Float64},
-		{"Close", reflect.Float64},
-		{"High", reflect.Float64},
-		{"Low", reflect.Float64},
-		{"WindowStart", reflect.Int64},
-		{"Transactions", reflect.Int64},
+		{ColumnName: "Symbol", ColumnType: reflect.String},
+		{ColumnName: "Volume", ColumnType: reflect.Int},
+		{ColumnName: "Open", ColumnType: reflect.Float64},
+		{ColumnName: "Close", ColumnType: reflect.Float64},
+		{ColumnName: "High", ColumnType: reflect.Float64},
+		{ColumnName: "Low", ColumnType: reflect.Float64},
+		{ColumnName: "WindowStart", ColumnType: reflect.Int64},
+		{ColumnName: "Transactions", ColumnType: reflect.Int64},
 	}
 )
 
 func createTestCSV(name string) (string, string, error) {
+	return createTestCSVWithContent(name, testCSV)
+}
+
+func createTestCSVWithContent(name, content string) (string, string, error) {
 	tempDir, err := os.MkdirTemp("", "fromcsvtest")
 	if err != nil {
 		return "", "", err
@@ -47,7 +55,7 @@ func createTestCSV(name string) (string, string, error) {
 		return "", "", fmt.Errorf("unable to open file %s: %w", filename, err)
 	}
 	defer f.Close()
-	_, err = f.WriteString(testCSV)
+	_, err = f.WriteString(content)
 	if err != nil {
 		return "", "", fmt.Errorf("unable to write test csv: %w", err)
 	}
@@ -216,3 +224,573 @@ func TestWriteCSV(t *testing.T) {
 	}
 
 }
+
+func TestFromCsvWithNulls(t *testing.T) {
+	content := `ticker,volume,open,close,high,low,window_start,transactions
+DFRAME,171463,17.74,17.675,17.81,17.675000,16383348000,452
+,NA,17.65,,17.655000,17.515000,16384212000,914
+DFRAME,151971,NA,17.72,17.755000,17.57,,535`
+	tempDir, testFileName, err := createTestCSVWithContent("nulls.csv", content)
+	if err != nil {
+		t.Fatalf("unable to generate test data: %s", err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	_, err = FromCSV(testFileName, *schema, true)
+	if err == nil {
+		t.Fatalf("expected an error reading NA without configuring it as a null value")
+		return
+	}
+	opts := CSVOptions{HasHeader: true, NullValues: []string{"", "NA"}}
+	df, err := FromCSVWithOptions(testFileName, *schema, opts)
+	if err != nil {
+		t.Fatalf("unable to create dataframe with nulls: %s", err)
+		return
+	}
+	if df.Length() != 3 {
+		t.Errorf("expected 3 rows, but found %d", df.Length())
+	}
+	nullCells := map[string]int{"Symbol": 1, "Volume": 1, "Close": 1, "Open": 2, "WindowStart": 2}
+	for _, columnName := range df.Names() {
+		for ndx := 0; ndx < df.Length(); ndx++ {
+			isNull, err := df.IsNull(columnName, ndx)
+			if err != nil {
+				t.Errorf("unable to check null for column %s index %d: %s", columnName, ndx, err)
+				continue
+			}
+			expectedNdx, ok := nullCells[columnName]
+			expected := ok && expectedNdx == ndx
+			if isNull != expected {
+				t.Errorf("expected null to be %t for column %s index %d, but found %t", expected, columnName, ndx, isNull)
+			}
+		}
+	}
+	_, ok, err := df.GetNullableIntValue("Volume", 1)
+	if err != nil || ok {
+		t.Errorf("expected Volume index 1 to be null, but found ok=%t err=%v", ok, err)
+	}
+	value, ok, err := df.GetNullableFloatValue("Close", 2)
+	if err != nil || !ok || value != 17.72 {
+		t.Errorf("expected Close index 2 to be 17.72, but found %f ok=%t err=%v", value, ok, err)
+	}
+	_, ok, err = df.GetNullableStringValue("Symbol", 1)
+	if err != nil || ok {
+		t.Errorf("expected Symbol index 1 to be null, but found ok=%t err=%v", ok, err)
+	}
+	_, ok, err = df.GetNullableBigIntValue("WindowStart", 2)
+	if err != nil || ok {
+		t.Errorf("expected WindowStart index 2 to be null, but found ok=%t err=%v", ok, err)
+	}
+	filtered, err := df.Filter("Close", Greater, 0.0)
+	if err != nil {
+		t.Fatalf("unable to filter dataframe with nulls: %s", err)
+		return
+	}
+	if filtered.Length() != 2 {
+		t.Errorf("expected null values to be excluded from the filter, but found %d rows", filtered.Length())
+	}
+	isNull, err := filtered.IsNull("Open", 1)
+	if err != nil || !isNull {
+		t.Errorf("expected nulls to be carried through a filter, but found %t (%v)", isNull, err)
+	}
+}
+
+func TestCSVChunkReader(t *testing.T) {
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	for _, chunkSize := range []int{3, 7} {
+		chunks, err := NewCSVChunkReader(strings.NewReader(testCSV), *schema, CSVOptions{HasHeader: true}, chunkSize)
+		if err != nil {
+			t.Fatalf("unable to create chunk reader: %s", err)
+			return
+		}
+		var lengths []int
+		var frames []*Dataframe
+		for {
+			df, err := chunks.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unable to read chunk: %s", err)
+				return
+			}
+			lengths = append(lengths, df.Length())
+			frames = append(frames, df)
+		}
+		expected := []int{3, 3, 1}
+		if chunkSize == 7 {
+			expected = []int{7}
+		}
+		if !slices.Equal(lengths, expected) {
+			t.Errorf("expected chunks of %v rows for a chunk size of %d, but found %v", expected, chunkSize, lengths)
+		}
+		df, err := Concat(frames...)
+		if err != nil {
+			t.Fatalf("unable to concat chunks: %s", err)
+			return
+		}
+		testIntHelper(t, "Volume", 5, 132764, df)
+	}
+}
+
+func TestFromCSVInfer(t *testing.T) {
+	tempDir, testFileName, err := createTestCSV("infer.csv")
+	if err != nil {
+		t.Fatalf("unable to generate test data: %s", err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	schema, err := InferSchema(testFileName, CSVOptions{HasHeader: true}, 0)
+	if err != nil {
+		t.Fatalf("unable to infer schema: %s", err)
+		return
+	}
+	expected := []SchemaDef{
+		{ColumnName: "ticker", ColumnType: reflect.String},
+		{ColumnName: "volume", ColumnType: reflect.Int},
+		{ColumnName: "open", ColumnType: reflect.Float64},
+		{ColumnName: "close", ColumnType: reflect.Float64},
+		{ColumnName: "high", ColumnType: reflect.Float64},
+		{ColumnName: "low", ColumnType: reflect.Float64},
+		{ColumnName: "window_start", ColumnType: reflect.Int64},
+		{ColumnName: "transactions", ColumnType: reflect.Int},
+	}
+	for ndx, def := range expected {
+		columnName, err := schema.ColumnFromIndex(ndx)
+		if err != nil || columnName != def.ColumnName {
+			t.Errorf("expected column %d to be %s, but found %s (%v)", ndx, def.ColumnName, columnName, err)
+		}
+	}
+	df, err := FromCSVInfer(testFileName, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read csv with an inferred schema: %s", err)
+		return
+	}
+	for _, def := range expected {
+		columnType, err := df.GetColumnType(def.ColumnName)
+		if err != nil || columnType != def.ColumnType {
+			t.Errorf("expected column %s to be %s, but found %s (%v)", def.ColumnName, def.ColumnType, columnType, err)
+		}
+	}
+	testBigIntHelper(t, "window_start", 2, 16385076000, df)
+	testFloatHelper(t, "close", 2, 17.72, df)
+	noHeader, err := InferSchema(testFileName, CSVOptions{}, 0)
+	if err != nil {
+		t.Fatalf("unable to infer schema without a header: %s", err)
+		return
+	}
+	columnName, _ := noHeader.ColumnFromIndex(1)
+	if columnName != "column_1" {
+		t.Errorf("expected generated column name column_1, but found %s", columnName)
+	}
+	if df, err := FromCSVInfer(testFileName, CSVOptions{}); err != nil || df.Length() != 8 {
+		t.Errorf("expected the header to be read as a string row without HasHeader, but found %v", err)
+	}
+}
+
+func TestFromCSVMatchHeader(t *testing.T) {
+	content := `Close, TICKER ,exchange,vol,window_start,open,high,low,transactions
+17.675,DFRAME,XNYS,171463,16383348000,17.74,17.81,17.675000,452
+17.58,DFRAME,XNYS,278397,16384212000,17.65,17.655000,17.515000,914`
+	tempDir, testFileName, err := createTestCSVWithContent("reordered.csv", content)
+	if err != nil {
+		t.Fatalf("unable to generate test data: %s", err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "ticker", ColumnType: reflect.String},
+		{ColumnName: "volume", ColumnType: reflect.Int},
+		{ColumnName: "open", ColumnType: reflect.Float64},
+		{ColumnName: "close", ColumnType: reflect.Float64},
+		{ColumnName: "window_start", ColumnType: reflect.Int64},
+	})
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	opts := CSVOptions{HasHeader: true, MatchHeader: true, Aliases: map[string][]string{"volume": {"vol", "qty"}}}
+	df, err := FromCSVWithOptions(testFileName, *schema, opts)
+	if err != nil {
+		t.Fatalf("unable to read csv by header: %s", err)
+		return
+	}
+	if !slices.Equal(df.Names(), schema.Names()) {
+		t.Errorf("expected columns %v, but found %v", schema.Names(), df.Names())
+	}
+	testStringHelper(t, "ticker", 1, "DFRAME", df)
+	testIntHelper(t, "volume", 1, 278397, df)
+	testFloatHelper(t, "open", 0, 17.74, df)
+	testFloatHelper(t, "close", 1, 17.58, df)
+	testBigIntHelper(t, "window_start", 0, 16383348000, df)
+	opts.Aliases = nil
+	_, err = FromCSVWithOptions(testFileName, *schema, opts)
+	var missing MissingHeaderError
+	if !errors.As(err, &missing) || missing.ColumnName != "volume" {
+		t.Errorf("expected a MissingHeaderError for volume, but found %v", err)
+	}
+}
+
+func TestFromCSVProjection(t *testing.T) {
+	tempDir, testFileName, err := createTestCSV("projection.csv")
+	if err != nil {
+		t.Fatalf("unable to generate test data: %s", err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	opts := CSVOptions{HasHeader: true, Columns: []string{"Close", "Symbol"}, ColumnIndices: []int{6}}
+	df, err := FromCSVWithOptions(testFileName, *schema, opts)
+	if err != nil {
+		t.Fatalf("unable to read a projection of the csv: %s", err)
+		return
+	}
+	expectedNames := []string{"Symbol", "Close", "WindowStart"}
+	if !slices.Equal(df.Names(), expectedNames) {
+		t.Errorf("expected columns %v, but found %v", expectedNames, df.Names())
+	}
+	if df.Length() != 7 {
+		t.Errorf("expected 7 rows, but found %d", df.Length())
+	}
+	testFloatHelper(t, "Close", 2, 17.72, df)
+	testBigIntHelper(t, "WindowStart", 2, 16385076000, df)
+	if _, err := FromCSVWithOptions(testFileName, *schema, CSVOptions{HasHeader: true, Columns: []string{"Missing"}}); err == nil {
+		t.Errorf("expected an error when selecting a missing column")
+	}
+	if _, err := FromCSVWithOptions(testFileName, *schema, CSVOptions{HasHeader: true, ColumnIndices: []int{8}}); err == nil {
+		t.Errorf("expected an error when selecting an index past the end of the schema")
+	}
+}
+
+func TestFromCSVDialect(t *testing.T) {
+	content := "\ufeffExported by vendor\n" +
+		"# generated nightly\n" +
+		"Symbol;Volume;Close\n" +
+		"DFRAME; 171463;17,675\n" +
+		"# a comment in the middle\n" +
+		"\"DF;RAME\"; 278397;17,58\n" +
+		"DFRAME; 151971;17,72\n"
+	tempDir, testFileName, err := createTestCSVWithContent("dialect.csv", content)
+	if err != nil {
+		t.Fatalf("unable to generate test data: %s", err)
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "Symbol", ColumnType: reflect.String},
+		{ColumnName: "Volume", ColumnType: reflect.Int},
+		{ColumnName: "Close", ColumnType: reflect.String},
+	})
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	opts := CSVOptions{
+		HasHeader:        true,
+		SkipRows:         1,
+		Delimiter:        ';',
+		Comment:          '#',
+		TrimLeadingSpace: true,
+		StripBOM:         true,
+		MaxRows:          2,
+		MatchHeader:      true,
+	}
+	df, err := FromCSVWithOptions(testFileName, *schema, opts)
+	if err != nil {
+		t.Fatalf("unable to read semicolon delimited csv: %s", err)
+		return
+	}
+	if df.Length() != 2 {
+		t.Errorf("expected MaxRows to limit the dataframe to 2 rows, but found %d", df.Length())
+	}
+	testStringHelper(t, "Symbol", 1, "DF;RAME", df)
+	testIntHelper(t, "Volume", 1, 278397, df)
+	testStringHelper(t, "Close", 0, "17,675", df)
+	opts.SkipRows = 0
+	opts.HeaderRow = 1
+	opts.MaxRows = 0
+	df, err = FromCSVWithOptions(testFileName, *schema, opts)
+	if err != nil {
+		t.Fatalf("unable to read csv with a header row index: %s", err)
+		return
+	}
+	if df.Length() != 3 {
+		t.Errorf("expected 3 rows, but found %d", df.Length())
+	}
+	inferred, err := InferSchema(testFileName, opts, 0)
+	if err != nil {
+		t.Fatalf("unable to infer schema with dialect options: %s", err)
+		return
+	}
+	if !slices.Equal(inferred.Names(), []string{"Symbol", "Volume", "Close"}) {
+		t.Errorf("expected the header after the skipped row to name the columns, but found %v", inferred.Names())
+	}
+	opts.StripBOM = false
+	opts.HeaderRow = 0
+	opts.MatchHeader = false
+	if _, err := FromCSVWithOptions(testFileName, *schema, opts); err == nil {
+		t.Errorf("expected an error when reading the title row as the header and the header as data")
+	}
+}
+
+func TestReadAndWriteWithoutFiles(t *testing.T) {
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	df, err := ReadCSV(strings.NewReader(testCSV), *schema, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read csv from a reader: %s", err)
+		return
+	}
+	var buffer bytes.Buffer
+	err = df.WriteCSVTo(&buffer, CSVWriteOptions{})
+	if err != nil {
+		t.Fatalf("unable to write csv to a buffer: %s", err)
+		return
+	}
+	roundTrip, err := ReadCSV(&buffer, *schema, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read back written csv: %s", err)
+		return
+	}
+	if roundTrip.Length() != df.Length() {
+		t.Errorf("expected %d rows after a round trip, but found %d", df.Length(), roundTrip.Length())
+	}
+	testFloatHelper(t, "High", 2, 17.755, roundTrip)
+	testBigIntHelper(t, "WindowStart", 6, 1639026, roundTrip)
+
+	fsys := fstest.MapFS{"fixtures/bars.csv": &fstest.MapFile{Data: []byte(testCSV)}}
+	fromFS, err := ReadCSVFS(fsys, "fixtures/bars.csv", *schema, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read csv from a file system: %s", err)
+		return
+	}
+	testIntHelper(t, "Volume", 3, 182507, fromFS)
+	inferred, err := ReadCSVInferFS(fsys, "fixtures/bars.csv", CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to infer csv from a file system: %s", err)
+		return
+	}
+	if inferred.Length() != 7 {
+		t.Errorf("expected the sampled rows to be replayed, but found %d rows", inferred.Length())
+	}
+	testBigIntHelper(t, "window_start", 0, 16383348000, inferred)
+	if _, err := ReadCSVFS(fsys, "missing.csv", *schema, CSVOptions{}); err == nil {
+		t.Errorf("expected an error when reading a missing file")
+	}
+}
+
+func TestReadCSVParallel(t *testing.T) {
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(testCSV), "\n")
+	var builder strings.Builder
+	builder.WriteString(lines[0] + "\n")
+	for ndx := 0; ndx < 1000; ndx++ {
+		row := lines[1+ndx%(len(lines)-1)]
+		if ndx%10 == 0 {
+			row = strings.Replace(row, "DFRAME", "", 1)
+		}
+		builder.WriteString(row + "\n")
+	}
+	content := builder.String()
+	sequential, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read csv sequentially: %s", err)
+		return
+	}
+	var expected bytes.Buffer
+	if err := sequential.WriteCSVTo(&expected, CSVWriteOptions{}); err != nil {
+		t.Fatalf("unable to write csv: %s", err)
+		return
+	}
+	opts := CSVOptions{HasHeader: true, Workers: 4, BatchSize: 7}
+	parallel, err := ReadCSV(strings.NewReader(content), *schema, opts)
+	if err != nil {
+		t.Fatalf("unable to read csv in parallel: %s", err)
+		return
+	}
+	var actual bytes.Buffer
+	if err := parallel.WriteCSVTo(&actual, CSVWriteOptions{}); err != nil {
+		t.Fatalf("unable to write csv: %s", err)
+		return
+	}
+	if actual.String() != expected.String() {
+		t.Errorf("expected parallel parsing to match sequential parsing")
+	}
+
+	chunks, err := NewCSVChunkReader(strings.NewReader(content), *schema, opts, 45)
+	if err != nil {
+		t.Fatalf("unable to create chunk reader: %s", err)
+		return
+	}
+	defer chunks.Close()
+	rows := 0
+	for {
+		df, err := chunks.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unable to read chunk: %s", err)
+			return
+		}
+		if df.Length() > 45 {
+			t.Errorf("expected at most 45 rows in a chunk, but found %d", df.Length())
+		}
+		rows += df.Length()
+	}
+	if rows != 1000 {
+		t.Errorf("expected 1000 rows across the chunks, but found %d", rows)
+	}
+
+	bad := strings.Split(content, "\n")
+	bad[801] = strings.Replace(bad[801], "17", "x", 1)
+	bad[501] = strings.Replace(bad[501], "17", "x", 1)
+	for attempt := 0; attempt < 5; attempt++ {
+		_, err = ReadCSV(strings.NewReader(strings.Join(bad, "\n")), *schema, opts)
+		if err == nil || !strings.Contains(err.Error(), "row 500:") {
+			t.Errorf("expected the error for the first bad row, but found %v", err)
+		}
+	}
+}
+
+func TestReadCSVErrorPolicy(t *testing.T) {
+	content := `ticker,volume,open,close,high,low,window_start,transactions
+DFRAME,171463,17.74,17.675,17.81,17.675000,16383348000,452
+DFRAME,n/a,17.74,17.675,17.81,17.675000,16383348000,452
+DFRAME,151971,17.62,17.72,17.755,17.57
+DFRAME,132764,17.6,bad,17.7,17.5,16385940000,528
+DFRAME,182507,17.665,17.63,17.74,17.63,16386804000,572
+`
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	if _, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true}); err == nil {
+		t.Errorf("expected the default policy to fail on the first bad row")
+	}
+	for _, workers := range []int{1, 3} {
+		opts := CSVOptions{HasHeader: true, OnError: ErrorSkip, Workers: workers, BatchSize: 1}
+		df, err := ReadCSV(strings.NewReader(content), *schema, opts)
+		if err != nil {
+			t.Fatalf("unable to read csv skipping bad rows: %s", err)
+			return
+		}
+		if df.Length() != 2 {
+			t.Errorf("expected 2 rows after skipping bad rows, but found %d", df.Length())
+		}
+		testIntHelper(t, "Volume", 1, 182507, df)
+
+		opts.OnError = ErrorNull
+		df, err = ReadCSV(strings.NewReader(content), *schema, opts)
+		if err != nil {
+			t.Fatalf("unable to read csv nulling bad cells: %s", err)
+			return
+		}
+		if df.Length() != 4 {
+			t.Errorf("expected 4 rows after nulling bad cells, but found %d", df.Length())
+		}
+		if null, _ := df.IsNull("Volume", 1); !null {
+			t.Errorf("expected the bad volume to be null")
+		}
+		if null, _ := df.IsNull("Close", 2); !null {
+			t.Errorf("expected the bad close to be null")
+		}
+
+		opts.OnError = ErrorCollect
+		df, rejects, err := ReadCSVWithRejects(strings.NewReader(content), *schema, opts)
+		if err != nil {
+			t.Fatalf("unable to read csv collecting bad rows: %s", err)
+			return
+		}
+		if df.Length() != 2 {
+			t.Errorf("expected 2 rows after collecting bad rows, but found %d", df.Length())
+		}
+		expected := []RejectedRow{
+			{Row: 1, Column: "Volume", Value: "n/a"},
+			{Row: 2, Value: "DFRAME,151971,17.62,17.72,17.755,17.57"},
+			{Row: 3, Column: "Close", Value: "bad"},
+		}
+		if len(rejects) != len(expected) {
+			t.Fatalf("expected %d rejected rows, but found %d", len(expected), len(rejects))
+			return
+		}
+		for ndx, reject := range rejects {
+			if reject.Row != expected[ndx].Row || reject.Column != expected[ndx].Column || reject.Value != expected[ndx].Value || reject.Err == nil {
+				t.Errorf("expected rejected row %+v, but found %+v", expected[ndx], reject)
+			}
+		}
+
+		// Rejected rows do not count towards MaxRows
+		opts.OnError = ErrorSkip
+		opts.MaxRows = 2
+		df, err = ReadCSV(strings.NewReader(content), *schema, opts)
+		if err != nil {
+			t.Fatalf("unable to read csv skipping bad rows with a limit: %s", err)
+			return
+		}
+		if df.Length() != 2 {
+			t.Errorf("expected MaxRows to count accepted rows, but found %d rows", df.Length())
+		}
+		testIntHelper(t, "Volume", 1, 182507, df)
+	}
+}
+
+func TestReadCSVErrorPolicyExtraFields(t *testing.T) {
+	content := `DFRAME,171463,17.74,17.675,17.81,17.675000,16383348000,452,extra
+DFRAME,182507,17.665,17.63,17.74,17.63,16386804000,572,extra
+`
+	schema, err := SchemaFromDefs(testFileSchemaDefs)
+	if err != nil {
+		t.Fatalf("unable to create test schema: %s", err)
+		return
+	}
+	if _, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{}); err == nil {
+		t.Errorf("expected the default policy to fail on a row with more fields than the schema")
+	}
+	for _, workers := range []int{1, 3} {
+		for _, policy := range []ErrorPolicy{ErrorSkip, ErrorNull, ErrorCollect} {
+			opts := CSVOptions{OnError: policy, Workers: workers, BatchSize: 1}
+			df, rejects, err := ReadCSVWithRejects(strings.NewReader(content), *schema, opts)
+			if err != nil {
+				t.Errorf("unable to read csv with extra fields under %s: %s", policy, err)
+				continue
+			}
+			if df.Length() != 0 {
+				t.Errorf("expected rows with extra fields to be dropped under %s, but found %d rows", policy, df.Length())
+			}
+			if policy != ErrorCollect {
+				continue
+			}
+			if len(rejects) != 2 {
+				t.Errorf("expected 2 rejected rows, but found %d", len(rejects))
+				continue
+			}
+			for ndx, reject := range rejects {
+				if reject.Row != ndx || reject.Column != "" || !strings.HasSuffix(reject.Value, ",extra") || reject.Err == nil {
+					t.Errorf("expected row %d to be rejected whole, but found %+v", ndx, reject)
+				}
+			}
+		}
+	}
+}
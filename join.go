@@ -0,0 +1,374 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// JoinType selects which rows are kept when joining two dataframes
+type JoinType string
+
+const (
+	// InnerJoin keeps rows whose keys appear in both dataframes
+	InnerJoin JoinType = "Inner"
+	// LeftJoin keeps every row of the left dataframe
+	LeftJoin JoinType = "Left"
+	// RightJoin keeps every row of the right dataframe
+	RightJoin JoinType = "Right"
+	// OuterJoin keeps every row of both dataframes
+	OuterJoin JoinType = "Outer"
+	// SemiJoin keeps the left rows that have a match, without adding any
+	// columns from the right dataframe
+	SemiJoin JoinType = "Semi"
+	// AntiJoin keeps the left rows that have no match, without adding any
+	// columns from the right dataframe
+	AntiJoin JoinType = "Anti"
+)
+
+const (
+	// DefaultLeftSuffix is added to overlapping left column names by Join
+	DefaultLeftSuffix = "_left"
+	// DefaultRightSuffix is added to overlapping right column names by Join
+	DefaultRightSuffix = "_right"
+)
+
+// Join combines the rows of two dataframes that hold equal values in the
+// key columns named by on.  The key columns must exist in both dataframes
+// with the same type, and null keys never match.  The result holds the key
+// columns, then the remaining left columns, then the remaining right
+// columns.  Columns other than the keys that appear in both dataframes are
+// renamed with DefaultLeftSuffix and DefaultRightSuffix.  Rows without a
+// match are filled with nulls
+func Join(left, right *Dataframe, on []string, how JoinType) (*Dataframe, error) {
+	return JoinWithSuffixes(left, right, on, how, DefaultLeftSuffix, DefaultRightSuffix)
+}
+
+// JoinWithSuffixes works like Join, but uses the suffixes provided to
+// rename overlapping columns
+func JoinWithSuffixes(left, right *Dataframe, on []string, how JoinType, leftSuffix, rightSuffix string) (*Dataframe, error) {
+	if len(on) == 0 {
+		return nil, fmt.Errorf("join requires at least one key column")
+	}
+	if leftSuffix == rightSuffix {
+		return nil, fmt.Errorf("join suffixes must be different, but both are %q", leftSuffix)
+	}
+	if err := checkJoinKeys(left, right, on); err != nil {
+		return nil, err
+	}
+	var leftRows, rightRows []int
+	switch how {
+	case InnerJoin, LeftJoin, OuterJoin, SemiJoin, AntiJoin:
+		rightIndex := right.indexRows(on)
+		matchedRight := make([]bool, right.numberRows)
+		for ndx := 0; ndx < left.numberRows; ndx++ {
+			matches := rightIndex[left.rowKey(on, ndx)]
+			if left.hasNullKey(on, ndx) {
+				matches = nil
+			}
+			switch how {
+			case SemiJoin:
+				if len(matches) > 0 {
+					leftRows = append(leftRows, ndx)
+				}
+				continue
+			case AntiJoin:
+				if len(matches) == 0 {
+					leftRows = append(leftRows, ndx)
+				}
+				continue
+			}
+			for _, match := range matches {
+				leftRows = append(leftRows, ndx)
+				rightRows = append(rightRows, match)
+				matchedRight[match] = true
+			}
+			if len(matches) == 0 && how != InnerJoin {
+				leftRows = append(leftRows, ndx)
+				rightRows = append(rightRows, -1)
+			}
+		}
+		if how == SemiJoin || how == AntiJoin {
+			return left.Take(leftRows)
+		}
+		if how == OuterJoin {
+			for ndx, matched := range matchedRight {
+				if !matched {
+					leftRows = append(leftRows, -1)
+					rightRows = append(rightRows, ndx)
+				}
+			}
+		}
+	case RightJoin:
+		leftIndex := left.indexRows(on)
+		for ndx := 0; ndx < right.numberRows; ndx++ {
+			matches := leftIndex[right.rowKey(on, ndx)]
+			if right.hasNullKey(on, ndx) {
+				matches = nil
+			}
+			for _, match := range matches {
+				leftRows = append(leftRows, match)
+				rightRows = append(rightRows, ndx)
+			}
+			if len(matches) == 0 {
+				leftRows = append(leftRows, -1)
+				rightRows = append(rightRows, ndx)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("join of type %s not supported", how)
+	}
+	return assembleJoin(left, right, on, leftRows, rightRows, leftSuffix, rightSuffix)
+}
+
+func checkJoinKeys(left, right *Dataframe, on []string) error {
+	for _, columnName := range on {
+		leftType, err := left.GetColumnType(columnName)
+		if err != nil {
+			return fmt.Errorf("left dataframe is missing key column %s: %w", columnName, err)
+		}
+		rightType, err := right.GetColumnType(columnName)
+		if err != nil {
+			return fmt.Errorf("right dataframe is missing key column %s: %w", columnName, err)
+		}
+		if leftType != rightType {
+			return WrongColumnTypeError{columnName, leftType, rightType}
+		}
+	}
+	return nil
+}
+
+// assembleJoin builds the joined dataframe from matching lists of left
+// and right row indices, where -1 marks a missing row
+func assembleJoin(left, right *Dataframe, on []string, leftRows, rightRows []int, leftSuffix, rightSuffix string) (*Dataframe, error) {
+	leftKeys, err := left.Select(on...)
+	if err != nil {
+		return nil, err
+	}
+	df, err := leftKeys.Take(leftRows)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(leftRows, -1) {
+		rightKeys, err := right.Select(on...)
+		if err != nil {
+			return nil, err
+		}
+		taken, err := rightKeys.Take(rightRows)
+		if err != nil {
+			return nil, err
+		}
+		for _, columnName := range on {
+			err = df.coalesceColumn(columnName, *taken)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	takenLeft, err := left.Take(leftRows)
+	if err != nil {
+		return nil, err
+	}
+	takenRight, err := right.Take(rightRows)
+	if err != nil {
+		return nil, err
+	}
+	for _, columnName := range left.Names() {
+		if slices.Contains(on, columnName) {
+			continue
+		}
+		outputName := columnName
+		if right.hasColumn(columnName) {
+			outputName += leftSuffix
+		}
+		err = df.addColumnFrom(*takenLeft, columnName, outputName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, columnName := range right.Names() {
+		if slices.Contains(on, columnName) {
+			continue
+		}
+		outputName := columnName
+		if left.hasColumn(columnName) {
+			outputName += rightSuffix
+		}
+		err = df.addColumnFrom(*takenRight, columnName, outputName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return df, nil
+}
+
+// indexRows maps the key of every row without a null key to the rows
+// that share it, in order
+func (d Dataframe) indexRows(columns []string) map[string][]int {
+	index := make(map[string][]int)
+	for ndx := 0; ndx < d.numberRows; ndx++ {
+		if d.hasNullKey(columns, ndx) {
+			continue
+		}
+		key := d.rowKey(columns, ndx)
+		index[key] = append(index[key], ndx)
+	}
+	return index
+}
+
+func (d Dataframe) hasNullKey(columns []string, ndx int) bool {
+	for _, columnName := range columns {
+		if d.isNullAt(columnName, ndx) {
+			return true
+		}
+	}
+	return false
+}
+
+// addColumnFrom adds a column of another dataframe to this one under a
+// new name.  The other dataframe must have the same number of rows
+func (d *Dataframe) addColumnFrom(other Dataframe, columnName, outputName string) error {
+	series, err := other.GetSeries(columnName)
+	if err != nil {
+		return err
+	}
+	return d.AddSeries(series.Rename(outputName))
+}
+
+// coalesceColumn fills the nulls of the named column with the values of
+// the column of the same name in another dataframe
+func (d *Dataframe) coalesceColumn(columnName string, other Dataframe) error {
+	series, err := coalesceSeries(d.series(columnName), other.series(columnName))
+	if err != nil {
+		return err
+	}
+	d.replaceSeries(series)
+	return nil
+}
+
+// coalesceSeries fills the nulls of a column by appending the other
+// column to a copy of it and taking each row from whichever half holds
+// a value
+func coalesceSeries(series, other Series) (Series, error) {
+	if other.Len() != series.Len() {
+		return nil, RowCountMismatchError{other.Name(), series.Len(), other.Len()}
+	}
+	combined, err := series.Take([]int{})
+	if err != nil {
+		return nil, err
+	}
+	if err := appendSeries(combined, series); err != nil {
+		return nil, err
+	}
+	if err := appendSeries(combined, other); err != nil {
+		return nil, err
+	}
+	return combined.Take(coalesceSources(validMask(series)))
+}
+
+// AsOfOptions controls how JoinAsOf matches rows
+type AsOfOptions struct {
+	// By names columns that must hold equal values in both dataframes
+	// before the nearest key is searched for, such as a ticker
+	By []string
+	// Tolerance is the largest gap allowed between the left key and the
+	// matched right key.  Nil allows any gap and zero only matches equal
+	// keys
+	Tolerance *int64
+	// LeftSuffix and RightSuffix rename overlapping columns, defaulting
+	// to DefaultLeftSuffix and DefaultRightSuffix
+	LeftSuffix  string
+	RightSuffix string
+}
+
+// JoinAsOf matches every row of the left dataframe to the right row with
+// the largest key that is less than or equal to the left key, such as the
+// most recent quote at the time of each trade.  The on column must be an
+// int, int64, Timestamp or Date column of the same type in both
+// dataframes.  Tolerance is in nanoseconds for Timestamp and Date
+// columns.  Neither dataframe needs to be sorted.  The result keeps the
+// rows of the left dataframe in their original order, with the By
+// columns first and the right columns filled with nulls where nothing
+// matched.  The on column of the right dataframe is not included
+func JoinAsOf(left, right *Dataframe, on string, opts AsOfOptions) (*Dataframe, error) {
+	if opts.LeftSuffix == "" {
+		opts.LeftSuffix = DefaultLeftSuffix
+	}
+	if opts.RightSuffix == "" {
+		opts.RightSuffix = DefaultRightSuffix
+	}
+	if slices.Contains(opts.By, on) {
+		return nil, fmt.Errorf("as-of column %s cannot also be a by column", on)
+	}
+	if opts.Tolerance != nil && *opts.Tolerance < 0 {
+		return nil, fmt.Errorf("as-of tolerance cannot be negative, but is %d", *opts.Tolerance)
+	}
+	if err := checkJoinKeys(left, right, append([]string{on}, opts.By...)); err != nil {
+		return nil, err
+	}
+	if onType := left.columnType(on); onType != reflect.Int && onType != reflect.Int64 && !isTimeKind(onType) {
+		return nil, WrongColumnTypeError{on, reflect.Int64, onType}
+	}
+	// Every group of right rows is sorted by key so that it can be
+	// searched for the nearest key
+	groups := right.indexRows(opts.By)
+	for key, rows := range groups {
+		rows = slices.DeleteFunc(rows, func(ndx int) bool { return right.isNullAt(on, ndx) })
+		slices.SortStableFunc(rows, func(a, b int) int {
+			return right.compareRows(on, a, b)
+		})
+		groups[key] = rows
+	}
+	leftRows := make([]int, left.numberRows)
+	rightRows := make([]int, left.numberRows)
+	for ndx := 0; ndx < left.numberRows; ndx++ {
+		leftRows[ndx] = ndx
+		rightRows[ndx] = -1
+		if left.isNullAt(on, ndx) || left.hasNullKey(opts.By, ndx) {
+			continue
+		}
+		rows := groups[left.rowKey(opts.By, ndx)]
+		leftKey := left.int64At(on, ndx)
+		// Find the first row with a key past the left key, the row
+		// before it is the nearest match
+		pos, _ := slices.BinarySearchFunc(rows, leftKey, func(row int, target int64) int {
+			if right.int64At(on, row) <= target {
+				return -1
+			}
+			return 1
+		})
+		if pos == 0 {
+			continue
+		}
+		match := rows[pos-1]
+		if opts.Tolerance != nil && leftKey-right.int64At(on, match) > *opts.Tolerance {
+			continue
+		}
+		rightRows[ndx] = match
+	}
+	var rightColumns []string
+	for _, columnName := range right.Names() {
+		if columnName != on {
+			rightColumns = append(rightColumns, columnName)
+		}
+	}
+	rightWithoutOn, err := right.Select(rightColumns...)
+	if err != nil {
+		return nil, err
+	}
+	return assembleJoin(left, rightWithoutOn, opts.By, leftRows, rightRows, opts.LeftSuffix, opts.RightSuffix)
+}
+
+// int64At returns the value of an int, int64 or time column as an int64
+func (d Dataframe) int64At(columnName string, ndx int) int64 {
+	switch d.columnType(columnName) {
+	case reflect.Int:
+		return int64(d.intColumn(columnName).data[ndx])
+	case reflect.Int64:
+		return d.bigIntColumn(columnName).data[ndx]
+	case Timestamp, Date:
+		return d.timeColumn(columnName).data[ndx]
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,212 @@
+package dataframe
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	joinPriceColumns = []testColumn{
+		{"Ticker", []string{"AAA", "BBB", "CCC", "AAA", ""}, []bool{true, true, true, true, false}},
+		{"Close", []float64{1, 2, 3, 4, 5}, nil},
+	}
+	joinReferenceColumns = []testColumn{
+		{"Ticker", []string{"AAA", "BBB", "DDD"}, nil},
+		{"Sector", []string{"Tech", "Energy", "Retail"}, nil},
+		{"Close", []float64{10, 20, 40}, nil},
+	}
+)
+
+func TestJoinTypes(t *testing.T) {
+	prices, reference := buildTestDataframe(t, joinPriceColumns...), buildTestDataframe(t, joinReferenceColumns...)
+	testCases := []struct {
+		how            JoinType
+		expectedLength int
+		expectedNames  int
+	}{
+		{InnerJoin, 3, 4},
+		{LeftJoin, 5, 4},
+		{RightJoin, 4, 4},
+		{OuterJoin, 6, 4},
+		{SemiJoin, 3, 2},
+		{AntiJoin, 2, 2},
+	}
+	for _, tc := range testCases {
+		joined, err := Join(prices, reference, []string{"Ticker"}, tc.how)
+		if err != nil {
+			t.Errorf("unable to perform %s join: %s", tc.how, err)
+			continue
+		}
+		if joined.Length() != tc.expectedLength {
+			t.Errorf("expected %d rows from %s join, but found %d", tc.expectedLength, tc.how, joined.Length())
+		}
+		if len(joined.Names()) != tc.expectedNames {
+			t.Errorf("expected %d columns from %s join, but found %v", tc.expectedNames, tc.how, joined.Names())
+		}
+	}
+}
+
+func TestJoinValues(t *testing.T) {
+	prices, reference := buildTestDataframe(t, joinPriceColumns...), buildTestDataframe(t, joinReferenceColumns...)
+	joined, err := Join(prices, reference, []string{"Ticker"}, OuterJoin)
+	if err != nil {
+		t.Fatalf("unable to perform outer join: %s", err)
+		return
+	}
+	expectedNames := []string{"Ticker", "Close_left", "Sector", "Close_right"}
+	for ndx, name := range joined.Names() {
+		if name != expectedNames[ndx] {
+			t.Errorf("expected column %d to be %s, but found %s", ndx, expectedNames[ndx], name)
+		}
+	}
+	testStringHelper(t, "Sector", 0, "Tech", joined)
+	testFloatHelper(t, "Close_right", 3, 10, joined)
+	if isNull, _ := joined.IsNull("Sector", 2); !isNull {
+		t.Errorf("expected an unmatched left row to have a null Sector")
+	}
+	// The unmatched right row comes last, with its key filled in
+	testStringHelper(t, "Ticker", 5, "DDD", joined)
+	testStringHelper(t, "Sector", 5, "Retail", joined)
+	if isNull, _ := joined.IsNull("Close_left", 5); !isNull {
+		t.Errorf("expected an unmatched right row to have a null Close_left")
+	}
+	// A null key never matches, but is kept by an outer join
+	if isNull, _ := joined.IsNull("Ticker", 4); !isNull {
+		t.Errorf("expected the null key to be kept as null")
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	prices, reference := buildTestDataframe(t, joinPriceColumns...), buildTestDataframe(t, joinReferenceColumns...)
+	if _, err := Join(prices, reference, []string{"Sector"}, InnerJoin); err == nil {
+		t.Errorf("expected an error when joining on a column missing from the left")
+	}
+	if _, err := Join(prices, reference, []string{"Close"}, InnerJoin); err != nil {
+		t.Errorf("expected joining on a shared float column to work, but found %s", err)
+	}
+	volumes, _ := NewColumn("Close", []int{1, 2, 3})
+	mismatched := New()
+	mismatched.AddIntColumn(*volumes)
+	if _, err := Join(prices, mismatched, []string{"Close"}, InnerJoin); err == nil {
+		t.Errorf("expected an error when key columns have different types")
+	}
+	if _, err := JoinWithSuffixes(prices, reference, []string{"Ticker"}, InnerJoin, "_x", "_x"); err == nil {
+		t.Errorf("expected an error when suffixes are the same")
+	}
+}
+
+func TestJoinAsOf(t *testing.T) {
+	trades := New()
+	tradeTickers, _ := NewColumn("Ticker", []string{"AAA", "BBB", "AAA", "AAA", "BBB"})
+	tradeTimes, _ := NewColumn("WindowStart", []int64{105, 100, 99, 130, 250})
+	sizes, _ := NewColumn("Size", []int{1, 2, 3, 4, 5})
+	for _, err := range []error{trades.AddStringColumn(*tradeTickers), trades.AddBigIntColumn(*tradeTimes), trades.AddIntColumn(*sizes)} {
+		if err != nil {
+			t.Fatalf("unable to build trade dataframe: %s", err)
+		}
+	}
+	quotes := New()
+	quoteTickers, _ := NewColumn("Ticker", []string{"AAA", "BBB", "AAA", "BBB", "AAA"})
+	quoteTimes, _ := NewColumn("WindowStart", []int64{120, 95, 100, 200, 104})
+	bids, _ := NewColumn("Bid", []float64{1.2, 2.0, 1.0, 2.5, 1.1})
+	for _, err := range []error{quotes.AddStringColumn(*quoteTickers), quotes.AddBigIntColumn(*quoteTimes), quotes.AddFloatColumn(*bids)} {
+		if err != nil {
+			t.Fatalf("unable to build quote dataframe: %s", err)
+		}
+	}
+	joined, err := JoinAsOf(trades, quotes, "WindowStart", AsOfOptions{By: []string{"Ticker"}})
+	if err != nil {
+		t.Fatalf("unable to perform as-of join: %s", err)
+		return
+	}
+	if joined.Length() != trades.Length() {
+		t.Errorf("expected %d rows, but found %d", trades.Length(), joined.Length())
+	}
+	expectedNames := []string{"Ticker", "WindowStart", "Size", "Bid"}
+	for ndx, name := range joined.Names() {
+		if name != expectedNames[ndx] {
+			t.Errorf("expected column %d to be %s, but found %s", ndx, expectedNames[ndx], name)
+		}
+	}
+	testFloatHelper(t, "Bid", 0, 1.1, joined)
+	testFloatHelper(t, "Bid", 1, 2.0, joined)
+	testFloatHelper(t, "Bid", 3, 1.2, joined)
+	testFloatHelper(t, "Bid", 4, 2.5, joined)
+	testBigIntHelper(t, "WindowStart", 3, 130, joined)
+	if isNull, _ := joined.IsNull("Bid", 2); !isNull {
+		t.Errorf("expected a trade before any quote to have a null Bid")
+	}
+	tolerance := int64(10)
+	withTolerance, err := JoinAsOf(trades, quotes, "WindowStart", AsOfOptions{By: []string{"Ticker"}, Tolerance: &tolerance})
+	if err != nil {
+		t.Fatalf("unable to perform as-of join with tolerance: %s", err)
+		return
+	}
+	testFloatHelper(t, "Bid", 3, 1.2, withTolerance)
+	if isNull, _ := withTolerance.IsNull("Bid", 4); !isNull {
+		t.Errorf("expected a quote outside the tolerance not to match")
+	}
+	exact := int64(0)
+	exactOnly, err := JoinAsOf(trades, quotes, "WindowStart", AsOfOptions{By: []string{"Ticker"}, Tolerance: &exact})
+	if err != nil {
+		t.Fatalf("unable to perform as-of join with zero tolerance: %s", err)
+		return
+	}
+	if isNull, _ := exactOnly.IsNull("Bid", 0); !isNull {
+		t.Errorf("expected a zero tolerance not to match a quote 1 before the trade")
+	}
+	exactOnly, err = JoinAsOf(quotes, quotes, "WindowStart", AsOfOptions{By: []string{"Ticker"}, Tolerance: &exact})
+	if err != nil {
+		t.Fatalf("unable to perform as-of join with zero tolerance: %s", err)
+		return
+	}
+	for ndx := 0; ndx < exactOnly.Length(); ndx++ {
+		if isNull, _ := exactOnly.IsNull("Bid"+DefaultRightSuffix, ndx); isNull {
+			t.Errorf("expected a zero tolerance to match the equal key on row %d", ndx)
+		}
+	}
+	negative := int64(-1)
+	if _, err := JoinAsOf(trades, quotes, "WindowStart", AsOfOptions{Tolerance: &negative}); err == nil {
+		t.Errorf("expected a negative tolerance to be rejected")
+	}
+	if _, err := JoinAsOf(trades, quotes, "Ticker", AsOfOptions{}); err == nil {
+		t.Errorf("expected an error when joining as-of on a string column")
+	}
+}
+
+func TestJoinAsOfDates(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC) }
+	trades := New()
+	tradeDays, _ := NewTimeColumn("Day", Date, TimeFormat{}, []time.Time{day(5), day(1), day(9)})
+	if err := trades.AddTimeColumn(*tradeDays); err != nil {
+		t.Fatalf("unable to build trade dataframe: %s", err)
+	}
+	rates := New()
+	rateDays, _ := NewTimeColumn("Day", Date, TimeFormat{}, []time.Time{day(2), day(4), day(8)})
+	values, _ := NewColumn("Rate", []float64{1.0, 2.0, 3.0})
+	for _, err := range []error{rates.AddTimeColumn(*rateDays), rates.AddFloatColumn(*values)} {
+		if err != nil {
+			t.Fatalf("unable to build rate dataframe: %s", err)
+		}
+	}
+	joined, err := JoinAsOf(trades, rates, "Day", AsOfOptions{})
+	if err != nil {
+		t.Fatalf("unable to perform as-of join on dates: %s", err)
+		return
+	}
+	testFloatHelper(t, "Rate", 0, 2.0, joined)
+	testFloatHelper(t, "Rate", 2, 3.0, joined)
+	if isNull, _ := joined.IsNull("Rate", 1); !isNull {
+		t.Errorf("expected a date before every rate to have a null Rate")
+	}
+	tolerance := int64(24 * time.Hour)
+	withTolerance, err := JoinAsOf(trades, rates, "Day", AsOfOptions{Tolerance: &tolerance})
+	if err != nil {
+		t.Fatalf("unable to perform as-of join on dates with tolerance: %s", err)
+		return
+	}
+	testFloatHelper(t, "Rate", 0, 2.0, withTolerance)
+	if isNull, _ := withTolerance.IsNull("Rate", 2); isNull {
+		t.Errorf("expected a rate one day earlier to be within the tolerance")
+	}
+}
@@ -0,0 +1,38 @@
+package dataframe
+
+import "reflect"
+
+// Timestamp, Date, Decimal and Categorical are the column types that
+// have no matching Go kind, so they are numbered past the kinds reflect
+// defines
+const (
+	Timestamp reflect.Kind = 100 + iota
+	Date
+	Decimal
+	Categorical
+)
+
+// kindName names a column type for error messages, including the types
+// that reflect does not know about and those added with RegisterType
+func kindName(kind reflect.Kind) string {
+	switch kind {
+	case Timestamp:
+		return "timestamp"
+	case Date:
+		return "date"
+	case Decimal:
+		return "decimal"
+	case Categorical:
+		return "categorical"
+	}
+	if kind >= firstRegisteredKind {
+		if name, ok := registeredName(kind); ok {
+			return name
+		}
+	}
+	return kind.String()
+}
+
+func isTimeKind(kind reflect.Kind) bool {
+	return kind == Timestamp || kind == Date
+}
@@ -0,0 +1,157 @@
+package dataframe
+
+import "math/bits"
+
+// Mask is a bitset holding one boolean per row.  Masks are produced by
+// comparing columns and can be combined with And, Or, Xor and Not before
+// being handed to Dataframe.Where to select rows
+type Mask struct {
+	bits   []uint64
+	length int
+}
+
+// Len will return the number of entries in the mask
+func (m Mask) Len() int {
+	return m.length
+}
+
+// Get will return the value of the mask at the ndx provided.  If the
+// index is out of bounds, it will return an IndexOutOfBounds error
+func (m Mask) Get(ndx int) (bool, error) {
+	if ndx < 0 || ndx >= m.length {
+		return false, IndexOutOfBounds{"mask", ndx, m.length}
+	}
+	return m.get(ndx), nil
+}
+
+// Set will set the value of the mask at the ndx provided.  If the
+// index is out of bounds, it will return an IndexOutOfBounds error
+func (m *Mask) Set(ndx int, value bool) error {
+	if ndx < 0 || ndx >= m.length {
+		return IndexOutOfBounds{"mask", ndx, m.length}
+	}
+	m.set(ndx, value)
+	return nil
+}
+
+// Count will return the number of entries in the mask that are true
+func (m Mask) Count() int {
+	count := 0
+	for _, word := range m.bits {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Indices will return the indices of every true entry in the mask
+func (m Mask) Indices() []int {
+	indices := make([]int, 0, m.Count())
+	for wordNdx, word := range m.bits {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			indices = append(indices, wordNdx*64+bit)
+			word &= word - 1
+		}
+	}
+	return indices
+}
+
+// And returns a new mask that is true where both masks are true.  The
+// masks must be the same length
+func (m Mask) And(other Mask) (Mask, error) {
+	return m.combine(other, func(a, b uint64) uint64 { return a & b })
+}
+
+// Or returns a new mask that is true where either mask is true.  The
+// masks must be the same length
+func (m Mask) Or(other Mask) (Mask, error) {
+	return m.combine(other, func(a, b uint64) uint64 { return a | b })
+}
+
+// Xor returns a new mask that is true where exactly one of the masks
+// is true.  The masks must be the same length
+func (m Mask) Xor(other Mask) (Mask, error) {
+	return m.combine(other, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// Not returns a new mask with every entry inverted
+func (m Mask) Not() Mask {
+	result := NewMask(m.length)
+	for ndx, word := range m.bits {
+		result.bits[ndx] = ^word
+	}
+	result.clearTail()
+	return result
+}
+
+func (m Mask) combine(other Mask, op func(a, b uint64) uint64) (Mask, error) {
+	if m.length != other.length {
+		return Mask{}, MaskLengthMismatchError{m.length, other.length}
+	}
+	result := NewMask(m.length)
+	for ndx := range m.bits {
+		result.bits[ndx] = op(m.bits[ndx], other.bits[ndx])
+	}
+	return result, nil
+}
+
+func (m *Mask) append(value bool) {
+	if m.length%64 == 0 {
+		m.bits = append(m.bits, 0)
+	}
+	m.length++
+	m.set(m.length-1, value)
+}
+
+func (m Mask) slice(start, stop int) Mask {
+	result := NewMask(stop - start)
+	for ndx := start; ndx < stop; ndx++ {
+		result.set(ndx-start, m.get(ndx))
+	}
+	return result
+}
+
+// truncate drops every entry from length onwards
+func (m *Mask) truncate(length int) {
+	m.bits = m.bits[:(length+63)/64]
+	m.length = length
+	m.clearTail()
+}
+
+func (m Mask) get(ndx int) bool {
+	return m.bits[ndx/64]&(1<<(ndx%64)) != 0
+}
+
+func (m *Mask) set(ndx int, value bool) {
+	if value {
+		m.bits[ndx/64] |= 1 << (ndx % 64)
+	} else {
+		m.bits[ndx/64] &^= 1 << (ndx % 64)
+	}
+}
+
+// clearTail zeroes the unused bits of the final word so that Count and
+// Indices never see entries past the length of the mask
+func (m *Mask) clearTail() {
+	if m.length%64 != 0 {
+		m.bits[len(m.bits)-1] &= (1 << (m.length % 64)) - 1
+	}
+}
+
+// NewMask will create a mask of the given length with every entry set
+// to false
+func NewMask(length int) Mask {
+	return Mask{
+		bits:   make([]uint64, (length+63)/64),
+		length: length,
+	}
+}
+
+// MaskFromBools will create a mask from a slice of booleans
+func MaskFromBools(values []bool) Mask {
+	m := NewMask(len(values))
+	for ndx, value := range values {
+		m.set(ndx, value)
+	}
+	return m
+}
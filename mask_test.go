@@ -0,0 +1,62 @@
+package dataframe
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMaskCombinators(t *testing.T) {
+	left := MaskFromBools([]bool{true, true, false, false})
+	right := MaskFromBools([]bool{true, false, true, false})
+	testCases := []struct {
+		name     string
+		combine  func() (Mask, error)
+		expected []int
+	}{
+		{"And", func() (Mask, error) { return left.And(right) }, []int{0}},
+		{"Or", func() (Mask, error) { return left.Or(right) }, []int{0, 1, 2}},
+		{"Xor", func() (Mask, error) { return left.Xor(right) }, []int{1, 2}},
+		{"Not", func() (Mask, error) { return left.Not(), nil }, []int{2, 3}},
+	}
+	for _, tc := range testCases {
+		result, err := tc.combine()
+		if err != nil {
+			t.Errorf("unable to combine masks with %s: %s", tc.name, err)
+			continue
+		}
+		if !slices.Equal(result.Indices(), tc.expected) {
+			t.Errorf("expected %s to produce indices %v, but found %v", tc.name, tc.expected, result.Indices())
+		}
+		if result.Count() != len(tc.expected) {
+			t.Errorf("expected %s to have a count of %d, but found %d", tc.name, len(tc.expected), result.Count())
+		}
+	}
+	_, err := left.And(NewMask(5))
+	var mismatch MaskLengthMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Errorf("expected a MaskLengthMismatchError when combining masks of different lengths, but found %v", err)
+	}
+}
+
+func TestMaskAcrossWords(t *testing.T) {
+	m := NewMask(130)
+	for _, ndx := range []int{0, 63, 64, 129} {
+		if err := m.Set(ndx, true); err != nil {
+			t.Fatalf("unable to set index %d: %s", ndx, err)
+		}
+	}
+	if err := m.Set(130, true); err == nil {
+		t.Errorf("expected an error when setting an index past the end of the mask")
+	}
+	if m.Count() != 4 {
+		t.Errorf("expected 4 entries to be set, but found %d", m.Count())
+	}
+	if m.Not().Count() != 126 {
+		t.Errorf("expected 126 entries to be set after Not, but found %d", m.Not().Count())
+	}
+	value, err := m.Get(64)
+	if err != nil || !value {
+		t.Errorf("expected index 64 to be set, but found %t (%v)", value, err)
+	}
+}
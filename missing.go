@@ -0,0 +1,209 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// DropHow controls when DropNA removes a row
+type DropHow string
+
+const (
+	// DropAny removes a row if any of the checked columns is null
+	DropAny DropHow = "Any"
+	// DropAll removes a row only if all of the checked columns are null
+	DropAll DropHow = "All"
+)
+
+// DropNA will return a pointer to a new dataframe without the rows that
+// contain nulls.  Only the columns in subset are checked, or every
+// column if subset is empty.  The how parameter decides whether a
+// single null (DropAny) or all nulls (DropAll) removes the row
+func (d Dataframe) DropNA(subset []string, how DropHow) (*Dataframe, error) {
+	columns, err := d.columnsOrAll(subset)
+	if err != nil {
+		return nil, err
+	}
+	keep := NewMask(d.numberRows).Not()
+	if how == DropAll {
+		keep = NewMask(d.numberRows)
+	}
+	for _, columnName := range columns {
+		switch how {
+		case DropAny:
+			keep, err = keep.And(validMask(d.series(columnName)))
+		case DropAll:
+			keep, err = keep.Or(validMask(d.series(columnName)))
+		default:
+			return nil, fmt.Errorf("drop of type %s not supported", how)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to check column %s for nulls: %w", columnName, err)
+		}
+	}
+	return d.Where(keep)
+}
+
+// FillNA will return a pointer to a new dataframe where the nulls in
+// each column named in values are replaced with the matching value.  The
+// values are converted to the type of the column in the same way as
+// Filter converts its values
+func (d Dataframe) FillNA(values map[string]interface{}) (*Dataframe, error) {
+	var replaced []Series
+	for columnName, value := range values {
+		series, err := d.GetSeries(columnName)
+		if err != nil {
+			return nil, err
+		}
+		var filled Series
+		if filler, ok := series.(nullFiller); ok {
+			filled, err = filler.fillNA(value)
+		} else {
+			filled, err = fillNullByParsing(series, value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		replaced = append(replaced, filled)
+	}
+	return d.replacing(replaced...)
+}
+
+// FillForward will return a pointer to a new dataframe where nulls in
+// the named columns, or every column if none are given, are replaced
+// by the last value above them that is not null
+func (d Dataframe) FillForward(columns ...string) (*Dataframe, error) {
+	return d.fillFrom(columns, fillForwardSources)
+}
+
+// FillBackward will return a pointer to a new dataframe where nulls in
+// the named columns, or every column if none are given, are replaced
+// by the next value below them that is not null
+func (d Dataframe) FillBackward(columns ...string) (*Dataframe, error) {
+	return d.fillFrom(columns, fillBackwardSources)
+}
+
+// fillFrom replaces every row of the named columns with the row that
+// sources picks for it from the valid rows, which works for every type
+// of column since it only needs Take
+func (d Dataframe) fillFrom(columns []string, sources func(valid Mask) []int) (*Dataframe, error) {
+	columns, err := d.columnsOrAll(columns)
+	if err != nil {
+		return nil, err
+	}
+	var replaced []Series
+	for _, columnName := range columns {
+		filled, err := d.series(columnName).Take(sources(validMask(d.series(columnName))))
+		if err != nil {
+			return nil, fmt.Errorf("unable to fill column %s: %w", columnName, err)
+		}
+		replaced = append(replaced, filled)
+	}
+	return d.replacing(replaced...)
+}
+
+// fillNullByParsing fills the nulls of a series that does not implement
+// nullFiller by parsing the value, formatted with fmt.Sprint, onto the end
+// of a copy and taking it for every null row
+func fillNullByParsing(series Series, value interface{}) (Series, error) {
+	indices := make([]int, series.Len())
+	for ndx := range indices {
+		indices[ndx] = ndx
+	}
+	combined, err := series.Take(indices)
+	if err != nil {
+		return nil, err
+	}
+	text := fmt.Sprint(value)
+	if err := combined.Parse(text); err != nil {
+		return nil, ParseError{-1, series.Name(), text, series.Type(), err}
+	}
+	for ndx := range indices {
+		if series.IsNull(ndx) {
+			indices[ndx] = series.Len()
+		}
+	}
+	return combined.Take(indices)
+}
+
+// Interpolate will return a pointer to a new dataframe where nulls in
+// the named numeric columns are filled by linear interpolation.  If no
+// columns are given, every numeric column is interpolated.  Asking for
+// a string column returns a WrongColumnTypeError
+func (d Dataframe) Interpolate(columns ...string) (*Dataframe, error) {
+	if len(columns) == 0 {
+		for _, series := range d.columns {
+			if isNumericKind(series.Type()) {
+				columns = append(columns, series.Name())
+			}
+		}
+	}
+	var replaced []Series
+	for _, columnName := range columns {
+		series, err := d.GetSeries(columnName)
+		if err != nil {
+			return nil, err
+		}
+		var interpolated Series
+		numeric, ok := series.(numericSeries)
+		if ok {
+			interpolated, ok = numeric.interpolate()
+		}
+		if !ok {
+			return nil, WrongColumnTypeError{columnName, reflect.Float64, series.Type()}
+		}
+		replaced = append(replaced, interpolated)
+	}
+	return d.replacing(replaced...)
+}
+
+// validMask returns a Mask that is true for every value of the series
+// that is not null
+func validMask(series Series) Mask {
+	if masker, ok := series.(interface{ ValidMask() Mask }); ok {
+		return masker.ValidMask()
+	}
+	mask := NewMask(series.Len())
+	for ndx := 0; ndx < series.Len(); ndx++ {
+		mask.set(ndx, !series.IsNull(ndx))
+	}
+	return mask
+}
+
+// columnsOrAll checks that every named column exists, returning all
+// columns in order when none are named
+func (d Dataframe) columnsOrAll(columns []string) ([]string, error) {
+	if len(columns) == 0 {
+		return d.Names(), nil
+	}
+	for _, columnName := range columns {
+		if _, err := d.GetColumnType(columnName); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+// replacing creates a new dataframe where each series given takes the
+// place of the column with the same name.  Every other column is copied,
+// so that appending to either dataframe never changes the other
+func (d Dataframe) replacing(replaced ...Series) (*Dataframe, error) {
+	byName := make(map[string]Series, len(replaced))
+	for _, series := range replaced {
+		byName[series.Name()] = series
+	}
+	df := New()
+	for _, series := range d.columns {
+		newSeries, ok := byName[series.Name()]
+		if !ok {
+			var err error
+			if newSeries, err = copySeries(series); err != nil {
+				return nil, fmt.Errorf("unable to copy column %s: %w", series.Name(), err)
+			}
+		}
+		df.columnIndex[series.Name()] = len(df.columns)
+		df.columns = append(df.columns, newSeries)
+	}
+	df.numberRows = d.numberRows
+	return df, nil
+}
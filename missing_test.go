@@ -0,0 +1,191 @@
+package dataframe
+
+import (
+	"testing"
+)
+
+var missingTestColumns = []testColumn{
+	{"Price", []float64{1.0, 0, 0, 4.0, 0}, []bool{true, false, false, true, false}},
+	{"Volume", []int{0, 10, 0, 30, 0}, []bool{false, true, false, true, false}},
+	{"Symbol", []string{"A", "", "C", "D", ""}, []bool{true, false, true, true, false}},
+}
+
+func TestDropNA(t *testing.T) {
+	df := buildTestDataframe(t, missingTestColumns...)
+	testCases := []struct {
+		subset         []string
+		how            DropHow
+		expectedLength int
+	}{
+		{nil, DropAny, 1},
+		{nil, DropAll, 4},
+		{[]string{"Price"}, DropAny, 2},
+		{[]string{"Price", "Symbol"}, DropAll, 3},
+	}
+	for _, tc := range testCases {
+		dropped, err := df.DropNA(tc.subset, tc.how)
+		if err != nil {
+			t.Errorf("unable to drop nulls for %v with %s: %s", tc.subset, tc.how, err)
+			continue
+		}
+		if dropped.Length() != tc.expectedLength {
+			t.Errorf("expected %d rows after dropping nulls for %v with %s, but found %d", tc.expectedLength, tc.subset, tc.how, dropped.Length())
+		}
+	}
+	if _, err := df.DropNA([]string{"Missing"}, DropAny); err == nil {
+		t.Errorf("expected an error when dropping on a missing column")
+	}
+}
+
+func TestFillNA(t *testing.T) {
+	df := buildTestDataframe(t, missingTestColumns...)
+	filled, err := df.FillNA(map[string]interface{}{"Price": 0, "Symbol": "?"})
+	if err != nil {
+		t.Fatalf("unable to fill nulls: %s", err)
+		return
+	}
+	testFloatHelper(t, "Price", 1, 0, filled)
+	testStringHelper(t, "Symbol", 4, "?", filled)
+	isNull, err := filled.IsNull("Volume", 0)
+	if err != nil || !isNull {
+		t.Errorf("expected Volume to be left alone by FillNA")
+	}
+	isNull, err = df.IsNull("Price", 1)
+	if err != nil || !isNull {
+		t.Errorf("expected FillNA to leave the original dataframe untouched")
+	}
+}
+
+func TestFillForwardAndBackward(t *testing.T) {
+	df := buildTestDataframe(t, missingTestColumns...)
+	forward, err := df.FillForward()
+	if err != nil {
+		t.Fatalf("unable to forward fill: %s", err)
+		return
+	}
+	testFloatHelper(t, "Price", 2, 1.0, forward)
+	testFloatHelper(t, "Price", 4, 4.0, forward)
+	testIntHelper(t, "Volume", 2, 10, forward)
+	if isNull, _ := forward.IsNull("Volume", 0); !isNull {
+		t.Errorf("expected a leading null to remain after a forward fill")
+	}
+	backward, err := df.FillBackward("Volume")
+	if err != nil {
+		t.Fatalf("unable to backward fill: %s", err)
+		return
+	}
+	testIntHelper(t, "Volume", 0, 10, backward)
+	testIntHelper(t, "Volume", 2, 30, backward)
+	if isNull, _ := backward.IsNull("Volume", 4); !isNull {
+		t.Errorf("expected a trailing null to remain after a backward fill")
+	}
+	if isNull, _ := backward.IsNull("Price", 1); !isNull {
+		t.Errorf("expected columns that were not named to be left alone")
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	df := buildTestDataframe(t, missingTestColumns...)
+	interpolated, err := df.Interpolate()
+	if err != nil {
+		t.Fatalf("unable to interpolate: %s", err)
+		return
+	}
+	testFloatHelper(t, "Price", 1, 2.0, interpolated)
+	testFloatHelper(t, "Price", 2, 3.0, interpolated)
+	testIntHelper(t, "Volume", 2, 20, interpolated)
+	if isNull, _ := interpolated.IsNull("Price", 4); !isNull {
+		t.Errorf("expected a trailing null to remain after interpolation")
+	}
+	if _, err := df.Interpolate("Symbol"); err == nil {
+		t.Errorf("expected an error when interpolating a string column")
+	}
+}
+
+func TestFilledDataframesCopyOtherColumns(t *testing.T) {
+	testCases := []struct {
+		name string
+		fill func(df *Dataframe) (*Dataframe, error)
+	}{
+		{"FillNA", func(df *Dataframe) (*Dataframe, error) {
+			return df.FillNA(map[string]interface{}{"Price": 0})
+		}},
+		{"FillForward", func(df *Dataframe) (*Dataframe, error) { return df.FillForward("Price") }},
+		{"FillBackward", func(df *Dataframe) (*Dataframe, error) { return df.FillBackward("Price") }},
+		{"Interpolate", func(df *Dataframe) (*Dataframe, error) { return df.Interpolate("Price") }},
+		{"ToCategorical", func(df *Dataframe) (*Dataframe, error) { return df.ToCategorical("Symbol") }},
+	}
+	for _, tc := range testCases {
+		df := buildTestDataframe(t, missingTestColumns...)
+		filled, err := tc.fill(df)
+		if err != nil {
+			t.Errorf("unable to %s: %s", tc.name, err)
+			continue
+		}
+		if err := filled.ParseValue("Volume", "40"); err != nil {
+			t.Errorf("unable to parse a value after %s: %s", tc.name, err)
+			continue
+		}
+		if err := filled.AppendNull("Volume"); err != nil {
+			t.Errorf("unable to append a null after %s: %s", tc.name, err)
+			continue
+		}
+		if length := df.series("Volume").Len(); length != 5 {
+			t.Errorf("expected appending to the result of %s to leave the original column with 5 rows, but found %d", tc.name, length)
+		}
+		if err := df.IsValid(); err != nil {
+			t.Errorf("expected the original dataframe to stay valid after appending to the result of %s: %s", tc.name, err)
+		}
+	}
+}
+
+func TestColumnFillsMatchDataframeFills(t *testing.T) {
+	df := buildTestDataframe(t, missingTestColumns...)
+	forward, err := df.FillForward("Price")
+	if err != nil {
+		t.Fatalf("unable to forward fill: %s", err)
+		return
+	}
+	backward, err := df.FillBackward("Price")
+	if err != nil {
+		t.Fatalf("unable to backward fill: %s", err)
+		return
+	}
+	price := df.floatColumn("Price")
+	for _, tc := range []struct {
+		name   string
+		column *Column[float64]
+		filled *Dataframe
+	}{
+		{"FillForward", price.FillForward(), forward},
+		{"FillBackward", price.FillBackward(), backward},
+	} {
+		for ndx := 0; ndx < price.Length(); ndx++ {
+			isNull, _ := tc.filled.IsNull("Price", ndx)
+			value, _ := tc.filled.floatColumn("Price").GetValueAtIndex(ndx)
+			got, _ := tc.column.GetValueAtIndex(ndx)
+			if tc.column.IsNull(ndx) != isNull || got != value {
+				t.Errorf("expected Column.%s to match the dataframe at row %d, but found %v and %v", tc.name, ndx, got, value)
+			}
+		}
+	}
+
+	first, _ := NewCategoricalColumn("Symbol", []string{"A"})
+	first.AppendNull()
+	first.AppendNull()
+	second, _ := NewCategoricalColumn("Symbol", []string{"X", "Y"})
+	second.AppendNull()
+	coalesced, err := first.Coalesce(*second)
+	if err != nil {
+		t.Fatalf("unable to coalesce categorical columns: %s", err)
+		return
+	}
+	for ndx, expected := range []string{"A", "Y", ""} {
+		if got, _ := coalesced.GetValueAtIndex(ndx); got != expected {
+			t.Errorf("expected %q at row %d after coalescing, but found %q", expected, ndx, got)
+		}
+	}
+	if !coalesced.IsNull(2) || len(first.Categories()) != 1 {
+		t.Errorf("expected a null in both columns to stay null and the original categories to be left alone")
+	}
+}
@@ -0,0 +1,264 @@
+package dataframe
+
+import (
+	"slices"
+)
+
+// storage is where a nullable column keeps its values.  Column keeps
+// them in a slice and BoolColumn packs them into a Mask, one bit per row
+type storage[T any, S any] interface {
+	*S
+	Len() int
+	get(ndx int) T
+	set(ndx int, val T)
+	append(val T)
+	slice(start, stop int) S
+	truncate(length int)
+}
+
+// values is the slice storage behind Column
+type values[T any] []T
+
+func (v values[T]) Len() int {
+	return len(v)
+}
+
+func (v values[T]) get(ndx int) T {
+	return v[ndx]
+}
+
+func (v *values[T]) set(ndx int, val T) {
+	(*v)[ndx] = val
+}
+
+func (v *values[T]) append(val T) {
+	*v = append(*v, val)
+}
+
+// slice copies the values from start to stop, so appending to or
+// filling either column never changes the other
+func (v values[T]) slice(start, stop int) values[T] {
+	return slices.Clone(v[start:stop])
+}
+
+func (v *values[T]) truncate(length int) {
+	*v = (*v)[:length]
+}
+
+// nullable holds the values of a column along with a validity bitmap,
+// which is only allocated once the first null is added.  It is what
+// Column and BoolColumn have in common, so they only differ in how the
+// values themselves are stored, compared and parsed
+type nullable[T any, S any, P storage[T, S]] struct {
+	data     S
+	validity Mask
+}
+
+// Length will return an integer representing the number of entries
+// in this column
+func (n nullable[T, S, P]) Length() int {
+	return P(&n.data).Len()
+}
+
+// IsNull will return true if the value at ndx is missing.  Indices
+// that are out of bounds are never null
+func (n nullable[T, S, P]) IsNull(ndx int) bool {
+	if !n.hasValidity() || ndx < 0 || ndx >= n.Length() {
+		return false
+	}
+	return !n.validity.get(ndx)
+}
+
+// IsValid will return true if the value at ndx is present
+func (n nullable[T, S, P]) IsValid(ndx int) bool {
+	return !n.IsNull(ndx)
+}
+
+// NullCount will return the number of missing values in the column
+func (n nullable[T, S, P]) NullCount() int {
+	if !n.hasValidity() {
+		return 0
+	}
+	return n.Length() - n.validity.Count()
+}
+
+// AppendValue will append the value provided to the column
+func (n *nullable[T, S, P]) AppendValue(val T) {
+	P(&n.data).append(val)
+	if n.hasValidity() {
+		n.validity.append(true)
+	}
+}
+
+// AppendNull will append a missing value to the column.  The zero
+// value of the column type is stored in its place
+func (n *nullable[T, S, P]) AppendNull() {
+	if !n.hasValidity() {
+		n.validity = NewMask(n.Length()).Not()
+	}
+	P(&n.data).append(*new(T))
+	n.validity.append(false)
+}
+
+// ValidMask will return a Mask that is true for every value in the
+// column that is not null
+func (n nullable[T, S, P]) ValidMask() Mask {
+	if !n.hasValidity() {
+		return NewMask(n.Length()).Not()
+	}
+	return n.validity.slice(0, n.Length())
+}
+
+// get returns the value stored at ndx, which is the zero value for a
+// null
+func (n nullable[T, S, P]) get(ndx int) T {
+	return P(&n.data).get(ndx)
+}
+
+// appendNullable appends every value of other, including its nulls
+func (n *nullable[T, S, P]) appendNullable(other nullable[T, S, P]) {
+	if !n.hasValidity() && other.hasValidity() {
+		n.validity = NewMask(n.Length()).Not()
+	}
+	for ndx := 0; ndx < other.Length(); ndx++ {
+		P(&n.data).append(other.get(ndx))
+		if n.hasValidity() {
+			n.validity.append(other.IsValid(ndx))
+		}
+	}
+}
+
+// slice copies the values and nulls from start to stop
+func (n nullable[T, S, P]) slice(start, stop int) nullable[T, S, P] {
+	sliced := nullable[T, S, P]{data: P(&n.data).slice(start, stop)}
+	if n.hasValidity() {
+		sliced.validity = n.validity.slice(start, stop)
+	}
+	return sliced
+}
+
+func (n nullable[T, S, P]) clone() nullable[T, S, P] {
+	return n.slice(0, n.Length())
+}
+
+// take returns the values at the provided indices, with -1 producing a
+// null.  Any other index that is out of bounds returns an
+// IndexOutOfBounds error naming the column
+func (n nullable[T, S, P]) take(columnName string, indices []int) (nullable[T, S, P], error) {
+	var taken nullable[T, S, P]
+	for _, ndx := range indices {
+		switch {
+		case ndx < -1 || ndx >= n.Length():
+			return taken, IndexOutOfBounds{columnName, ndx, n.Length()}
+		case ndx == -1 || n.IsNull(ndx):
+			taken.AppendNull()
+		default:
+			taken.AppendValue(n.get(ndx))
+		}
+	}
+	return taken, nil
+}
+
+// fillNull replaces every null with the value provided
+func (n nullable[T, S, P]) fillNull(value T) nullable[T, S, P] {
+	filled := nullable[T, S, P]{data: P(&n.data).slice(0, n.Length())}
+	for ndx := 0; ndx < n.Length(); ndx++ {
+		if n.IsNull(ndx) {
+			P(&filled.data).set(ndx, value)
+		}
+	}
+	return filled
+}
+
+// fillForward replaces every null with the last value before it.  Nulls
+// at the start have nothing to fill from and remain null
+func (n nullable[T, S, P]) fillForward() nullable[T, S, P] {
+	// The sources are always in bounds, so take cannot fail
+	filled, _ := n.take("", fillForwardSources(n.ValidMask()))
+	return filled
+}
+
+// fillBackward replaces every null with the next value after it.  Nulls
+// at the end have nothing to fill from and remain null
+func (n nullable[T, S, P]) fillBackward() nullable[T, S, P] {
+	filled, _ := n.take("", fillBackwardSources(n.ValidMask()))
+	return filled
+}
+
+// coalesce replaces every null with the value at the same index in
+// other, which must be the same length, if that value is present
+func (n nullable[T, S, P]) coalesce(other nullable[T, S, P]) nullable[T, S, P] {
+	combined := n.clone()
+	combined.appendNullable(other)
+	coalesced, _ := combined.take("", coalesceSources(n.ValidMask()))
+	return coalesced
+}
+
+// compareNulls orders two rows when either of them is null, with nulls
+// sorting after every other value.  The bool is false when both values
+// are present and have to be compared by the column
+func (n nullable[T, S, P]) compareNulls(i, j int) (int, bool) {
+	iNull, jNull := n.IsNull(i), n.IsNull(j)
+	switch {
+	case iNull && jNull:
+		return 0, true
+	case iNull:
+		return 1, true
+	case jNull:
+		return -1, true
+	}
+	return 0, false
+}
+
+func (n nullable[T, S, P]) hasValidity() bool {
+	return n.validity.Len() > 0
+}
+
+// truncate drops every value from length onwards
+func (n *nullable[T, S, P]) truncate(length int) {
+	P(&n.data).truncate(length)
+	if n.hasValidity() {
+		n.validity.truncate(length)
+	}
+}
+
+// fillForwardSources returns, for each row, the index of the last valid
+// row at or before it, or -1 when there is none
+func fillForwardSources(valid Mask) []int {
+	sources := make([]int, valid.Len())
+	last := -1
+	for ndx := range sources {
+		if valid.get(ndx) {
+			last = ndx
+		}
+		sources[ndx] = last
+	}
+	return sources
+}
+
+// fillBackwardSources returns, for each row, the index of the next valid
+// row at or after it, or -1 when there is none
+func fillBackwardSources(valid Mask) []int {
+	sources := make([]int, valid.Len())
+	next := -1
+	for ndx := len(sources) - 1; ndx >= 0; ndx-- {
+		if valid.get(ndx) {
+			next = ndx
+		}
+		sources[ndx] = next
+	}
+	return sources
+}
+
+// coalesceSources returns, for each row, its own index when it is valid
+// and the index of the same row in a column appended after it otherwise
+func coalesceSources(valid Mask) []int {
+	sources := make([]int, valid.Len())
+	for ndx := range sources {
+		sources[ndx] = ndx
+		if !valid.get(ndx) {
+			sources[ndx] += valid.Len()
+		}
+	}
+	return sources
+}
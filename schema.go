@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"reflect"
 	"slices"
+	"time"
 )
 
-// SchemaDef is a type that contains column name and type
+// SchemaDef is a type that contains column name and type.  Scale is
+// the number of decimal places of a Decimal column and must be left at
+// zero for every other type
 type SchemaDef struct {
 	ColumnName string
 	ColumnType reflect.Kind
+	Scale      int
 }
 
 // Schema is used to determine the type of variables
@@ -17,28 +21,94 @@ type SchemaDef struct {
 type Schema struct {
 	columnOrder []string
 	columnType  []reflect.Kind
+	timeFormats map[string]TimeFormat
+	scales      map[string]int
 }
 
 func (s Schema) isAllowedType(columnType reflect.Kind) bool {
-	switch columnType {
-	case reflect.String, reflect.Int, reflect.Int64, reflect.Float64:
-		return true
-	default:
-		return false
-	}
+	return isRegistered(columnType)
 }
 
 // AddColumn takes a name and Kind and stores it
-// in the schema
+// in the schema.  A name that is already in the schema is rejected
+// with a ColumnAlreadyExists error, which also applies to FromMap and
+// SchemaFromDefs
 func (s *Schema) AddColumn(columnName string, columnType reflect.Kind) error {
 	if !s.isAllowedType(columnType) {
 		return UnsupportedType{ColumnType: columnType}
 	}
+	if slices.Contains(s.columnOrder, columnName) {
+		return ColumnAlreadyExists{columnName}
+	}
 	s.columnOrder = append(s.columnOrder, columnName)
 	s.columnType = append(s.columnType, columnType)
 	return nil
 }
 
+// AddTimeColumn adds a Timestamp or Date column that is parsed and
+// written using the format provided
+func (s *Schema) AddTimeColumn(columnName string, columnType reflect.Kind, format TimeFormat) error {
+	if !isTimeKind(columnType) {
+		return UnsupportedType{ColumnType: columnType}
+	}
+	err := s.AddColumn(columnName, columnType)
+	if err != nil {
+		return err
+	}
+	return s.SetTimeFormat(columnName, format)
+}
+
+// SetTimeFormat changes the format of an existing Timestamp or Date
+// column.  Time columns without a format use the defaults described on
+// TimeFormat
+func (s *Schema) SetTimeFormat(columnName string, format TimeFormat) error {
+	ndx := slices.Index(s.columnOrder, columnName)
+	if ndx < 0 {
+		return MissingColumnError{ColumnName: columnName}
+	}
+	if !isTimeKind(s.columnType[ndx]) {
+		return WrongColumnTypeError{columnName, Timestamp, s.columnType[ndx]}
+	}
+	if s.timeFormats == nil {
+		s.timeFormats = make(map[string]TimeFormat)
+	}
+	s.timeFormats[columnName] = format
+	return nil
+}
+
+// AddDecimalColumn adds a Decimal column holding values with the given
+// number of decimal places
+func (s *Schema) AddDecimalColumn(columnName string, scale int) error {
+	if scale < 0 || scale > MaxDecimalScale {
+		return fmt.Errorf("scale %d of column %s must be between 0 and %d", scale, columnName, MaxDecimalScale)
+	}
+	err := s.AddColumn(columnName, Decimal)
+	if err != nil {
+		return err
+	}
+	return s.SetScale(columnName, scale)
+}
+
+// SetScale changes the number of decimal places of an existing Decimal
+// column.  Decimal columns without a scale hold whole numbers
+func (s *Schema) SetScale(columnName string, scale int) error {
+	ndx := slices.Index(s.columnOrder, columnName)
+	if ndx < 0 {
+		return MissingColumnError{ColumnName: columnName}
+	}
+	if s.columnType[ndx] != Decimal {
+		return WrongColumnTypeError{columnName, Decimal, s.columnType[ndx]}
+	}
+	if scale < 0 || scale > MaxDecimalScale {
+		return fmt.Errorf("scale %d of column %s must be between 0 and %d", scale, columnName, MaxDecimalScale)
+	}
+	if s.scales == nil {
+		s.scales = make(map[string]int)
+	}
+	s.scales[columnName] = scale
+	return nil
+}
+
 // FromMap takes a map[string]reflect.Kind and adds the columns
 // This could have order issues, so be careful.  At the time of
 // writing, order is usually preserved but not guaranteed
@@ -67,13 +137,20 @@ func (s *Schema) ReorderColumns(newOrder []string) error {
 		return fmt.Errorf("expected new order to have %d entries, but found %d", len(s.columnOrder), len(newOrder))
 	}
 	var orderToBeSet []string
+	var typesToBeSet []reflect.Kind
 	for _, columnName := range newOrder {
-		if !slices.Contains(s.columnOrder, columnName) {
-			return fmt.Errorf("column %s has been given, but does not currently exist", columnName)
+		ndx := slices.Index(s.columnOrder, columnName)
+		if ndx < 0 {
+			return MissingColumnError{ColumnName: columnName}
+		}
+		if slices.Contains(orderToBeSet, columnName) {
+			return fmt.Errorf("column %s has been given more than once: %w", columnName, ErrColumnExists)
 		}
 		orderToBeSet = append(orderToBeSet, columnName)
+		typesToBeSet = append(typesToBeSet, s.columnType[ndx])
 	}
 	s.columnOrder = orderToBeSet
+	s.columnType = typesToBeSet
 	return nil
 }
 
@@ -82,39 +159,39 @@ func (s *Schema) ReorderColumns(newOrder []string) error {
 func (s Schema) BuildDF() (*Dataframe, error) {
 	df := New()
 	for ndx, columnName := range s.columnOrder {
-		columnType := s.columnType[ndx]
-		switch columnType {
-		case reflect.String:
-			col, err := NewColumn[string](columnName, []string{})
-			if err != nil {
-				return nil, err
-			}
-			df.AddStringColumn(*col)
-		case reflect.Int:
-			col, err := NewColumn[int](columnName, []int{})
-			if err != nil {
-				return nil, err
-			}
-			df.AddIntColumn(*col)
-		case reflect.Int64:
-			col, err := NewColumn[int64](columnName, []int64{})
-			if err != nil {
-				return nil, err
-			}
-			df.AddBigIntColumn(*col)
-		case reflect.Float64:
-			col, err := NewColumn[float64](columnName, []float64{})
-			if err != nil {
-				return nil, err
-			}
-			df.AddFloatColumn(*col)
-		default:
-			return nil, UnsupportedType{ColumnType: columnType}
+		series, err := s.newSeries(columnName, s.columnType[ndx])
+		if err != nil {
+			return nil, err
+		}
+		err = df.AddSeries(series)
+		if err != nil {
+			return nil, err
 		}
 	}
 	return df, nil
 }
 
+// newSeries creates an empty column of the type given, using the time
+// format or scale set for it in the schema
+func (s Schema) newSeries(columnName string, columnType reflect.Kind) (Series, error) {
+	switch columnType {
+	case Timestamp, Date:
+		col, err := NewTimeColumn(columnName, columnType, s.timeFormats[columnName], []time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		return timeSeries{col}, nil
+	case Decimal:
+		col, err := NewDecimalColumn(columnName, s.scales[columnName], []DecimalValue{})
+		if err != nil {
+			return nil, err
+		}
+		return decimalSeries{col}, nil
+	default:
+		return newSeries(columnName, columnType)
+	}
+}
+
 // ColumnFromIndex retrieves the column name from the index provided
 // and errors otherwise
 func (s Schema) ColumnFromIndex(ndx int) (string, error) {
@@ -134,6 +211,12 @@ func SchemaFromDefs(defs []SchemaDef) (*Schema, error) {
 		if err != nil {
 			return nil, err
 		}
+		if def.Scale != 0 || def.ColumnType == Decimal {
+			err = s.SetScale(def.ColumnName, def.Scale)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	return &s, nil
 }
@@ -0,0 +1,64 @@
+package dataframe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaRejectsDuplicateColumns(t *testing.T) {
+	schema := Schema{}
+	if err := schema.AddColumn("Symbol", reflect.String); err != nil {
+		t.Fatalf("unable to add column: %s", err)
+		return
+	}
+	if err := schema.AddColumn("Symbol", reflect.Int); !errors.Is(err, ErrColumnExists) {
+		t.Errorf("expected adding Symbol twice to be rejected, but found %v", err)
+	}
+	if len(schema.Names()) != 1 {
+		t.Errorf("expected the rejected column not to be added, but found %v", schema.Names())
+	}
+	_, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "Open", ColumnType: reflect.Float64},
+		{ColumnName: "Open", ColumnType: reflect.Float64},
+	})
+	if !errors.Is(err, ErrColumnExists) {
+		t.Errorf("expected SchemaFromDefs to reject a repeated name, but found %v", err)
+	}
+	if err := schema.FromMap(map[string]reflect.Kind{"Symbol": reflect.String}); !errors.Is(err, ErrColumnExists) {
+		t.Errorf("expected FromMap to reject a name already in the schema, but found %v", err)
+	}
+}
+
+func TestSchemaReorderColumnsKeepsTypes(t *testing.T) {
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "Symbol", ColumnType: reflect.String},
+		{ColumnName: "Volume", ColumnType: reflect.Int},
+		{ColumnName: "Close", ColumnType: reflect.Float64},
+	})
+	if err != nil {
+		t.Fatalf("unable to create schema: %s", err)
+		return
+	}
+	if err := schema.ReorderColumns([]string{"Close", "Symbol", "Volume"}); err != nil {
+		t.Fatalf("unable to reorder schema: %s", err)
+		return
+	}
+	df, err := schema.BuildDF()
+	if err != nil {
+		t.Fatalf("unable to build dataframe: %s", err)
+		return
+	}
+	expected := map[string]reflect.Kind{"Close": reflect.Float64, "Symbol": reflect.String, "Volume": reflect.Int}
+	for ndx, name := range df.Names() {
+		if name != schema.Names()[ndx] {
+			t.Errorf("expected column %d to be %s, but found %s", ndx, schema.Names()[ndx], name)
+		}
+		if columnType, _ := df.GetColumnType(name); columnType != expected[name] {
+			t.Errorf("expected %s to keep type %s, but found %s", name, expected[name], columnType)
+		}
+	}
+	if err := schema.ReorderColumns([]string{"Close", "Close", "Volume"}); !errors.Is(err, ErrColumnExists) {
+		t.Errorf("expected a repeated name to be rejected, but found %v", err)
+	}
+}
@@ -0,0 +1,398 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// Series is a single named column of any type, built in or registered
+// with RegisterType.  A Dataframe stores its columns as Series, so any
+// type that satisfies this interface can be read from a CSV, sliced,
+// filtered, joined, concatenated and written like the built in types.
+// Format is only called for values that are not null, and Parse must
+// return an error rather than append anything when the value cannot be
+// converted
+type Series interface {
+	Name() string
+	Type() reflect.Kind
+	Len() int
+	IsNull(ndx int) bool
+	Parse(value string) error
+	AppendNull()
+	Format(ndx int) string
+	Slice(start, stop int) (Series, error)
+	Take(indices []int) (Series, error)
+	Rename(name string) Series
+}
+
+// ValueComparer is implemented by a Series that can be used with
+// Compare and Filter.  The values are given exactly as passed to
+// Compare, so the series is responsible for converting them
+type ValueComparer interface {
+	CompareValues(operation FilterType, values ...interface{}) (Mask, error)
+}
+
+// SeriesComparer is implemented by a Series that can be compared row by
+// row against another Series of the same type with CompareColumns
+type SeriesComparer interface {
+	CompareSeries(operation FilterType, other Series) (Mask, error)
+}
+
+// RowComparer is implemented by a Series that can be sorted.  It
+// returns a negative number when row i sorts before row j, a positive
+// number when it sorts after and zero when they are equal, with nulls
+// sorting last
+type RowComparer interface {
+	CompareRows(i, j int) int
+}
+
+// RowKeyer is implemented by a Series whose values need a different
+// key than Format when grouping or joining, for instance so that equal
+// values written differently match.  Series without it are keyed by
+// Format
+type RowKeyer interface {
+	Key(ndx int) string
+}
+
+// SeriesAppender is implemented by a Series that can append the values
+// of another Series of the same type directly.  Series without it are
+// concatenated by formatting and parsing every value
+type SeriesAppender interface {
+	AppendSeries(other Series) error
+}
+
+// truncater is implemented by the built in series so a partial row can
+// be dropped in place rather than by slicing
+type truncater interface {
+	truncate(length int)
+}
+
+// displayer is implemented by the built in series so tables show raw
+// numbers rather than their formatted text
+type displayer interface {
+	display(ndx int) interface{}
+}
+
+// nullFiller is implemented by the built in series so that FillNA
+// converts its value the same way Filter does.  Other series parse the
+// value formatted with fmt.Sprint
+type nullFiller interface {
+	fillNA(value interface{}) (Series, error)
+}
+
+// numericSeries is implemented by the built in series, but only the
+// numeric ones return true, for sums, statistics and interpolation
+type numericSeries interface {
+	sumGroups(groups [][]int, outputName string) (Series, bool, error)
+	float64Column() (*Column[float64], bool)
+	interpolate() (Series, bool)
+}
+
+// SeriesFactory creates an empty Series with the column name provided
+type SeriesFactory func(columnName string) (Series, error)
+
+// firstRegisteredKind leaves room after the custom kinds defined in
+// kinds.go for further built in types
+const firstRegisteredKind reflect.Kind = 200
+
+var registry = struct {
+	sync.RWMutex
+	factories map[reflect.Kind]SeriesFactory
+	names     map[reflect.Kind]string
+	next      reflect.Kind
+}{
+	factories: map[reflect.Kind]SeriesFactory{
+		reflect.String:  newColumnSeries[string],
+		reflect.Int:     newColumnSeries[int],
+		reflect.Int64:   newColumnSeries[int64],
+		reflect.Float64: newColumnSeries[float64],
+		reflect.Bool:    newBoolSeries,
+		Timestamp:       func(columnName string) (Series, error) { return newTimeSeries(columnName, Timestamp) },
+		Date:            func(columnName string) (Series, error) { return newTimeSeries(columnName, Date) },
+		Decimal:         newDecimalSeries,
+		Categorical:     newCategoricalSeries,
+	},
+	names: make(map[reflect.Kind]string),
+	next:  firstRegisteredKind,
+}
+
+// RegisterType adds a column type, such as a UUID or an IP address,
+// that is not built in and returns the Kind that identifies it.  Once
+// registered, the Kind can be used in a Schema and the columns it makes
+// are read and written like any other.  Columns built elsewhere are
+// added with Dataframe.AddSeries.  The name is used in error messages
+// and must be unique
+func RegisterType(name string, factory SeriesFactory) (reflect.Kind, error) {
+	if name == "" {
+		return reflect.Invalid, fmt.Errorf("column type must have a name")
+	}
+	if factory == nil {
+		return reflect.Invalid, fmt.Errorf("column type %s must have a factory", name)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	for kind := range registry.factories {
+		existing, ok := registry.names[kind]
+		if !ok {
+			existing = kindName(kind)
+		}
+		if existing == name {
+			return reflect.Invalid, fmt.Errorf("column type %s is already registered", name)
+		}
+	}
+	kind := registry.next
+	registry.next++
+	registry.factories[kind] = factory
+	registry.names[kind] = name
+	return kind, nil
+}
+
+// registeredName returns the name a Kind was registered with
+func registeredName(kind reflect.Kind) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	name, ok := registry.names[kind]
+	return name, ok
+}
+
+// isRegistered reports whether columns of the Kind can be created
+func isRegistered(kind reflect.Kind) bool {
+	registry.RLock()
+	defer registry.RUnlock()
+	_, ok := registry.factories[kind]
+	return ok
+}
+
+// newSeries creates an empty Series of the Kind provided, returning an
+// UnsupportedType error if the Kind is neither built in nor registered
+func newSeries(columnName string, kind reflect.Kind) (Series, error) {
+	registry.RLock()
+	factory, ok := registry.factories[kind]
+	registry.RUnlock()
+	if !ok {
+		return nil, UnsupportedType{kind}
+	}
+	series, err := factory(columnName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create column %s of type %s: %w", columnName, kindName(kind), err)
+	}
+	if series == nil || series.Type() != kind {
+		return nil, fmt.Errorf("factory for %s did not create a column of that type", kindName(kind))
+	}
+	return series, nil
+}
+
+// copySeries returns a copy of series that can be appended to without
+// changing it.  Take is used since every Series returns a new column
+// from it
+func copySeries(series Series) (Series, error) {
+	return series.Take(NewMask(series.Len()).Not().Indices())
+}
+
+// checkSeriesType makes sure a series has a registered type and, when
+// that type is built in, is the series this package creates for it, as
+// the typed accessors of Dataframe rely on it
+func checkSeriesType(series Series) error {
+	kind := series.Type()
+	if !isRegistered(kind) {
+		return UnsupportedType{kind}
+	}
+	if kind >= firstRegisteredKind {
+		return nil
+	}
+	builtin, err := newSeries(series.Name(), kind)
+	if err != nil {
+		return err
+	}
+	if reflect.TypeOf(series) != reflect.TypeOf(builtin) {
+		return fmt.Errorf("column %s is a %T, but the built in type %s needs a %T: %w", series.Name(), series, kindName(kind), builtin, ErrWrongColumnType)
+	}
+	return nil
+}
+
+// appendSeries appends every value of source to target, which must be
+// of the same type
+func appendSeries(target, source Series) error {
+	if appender, ok := target.(SeriesAppender); ok {
+		return appender.AppendSeries(source)
+	}
+	for ndx := 0; ndx < source.Len(); ndx++ {
+		if source.IsNull(ndx) {
+			target.AppendNull()
+			continue
+		}
+		if err := target.Parse(source.Format(ndx)); err != nil {
+			return ParseError{ndx, source.Name(), source.Format(ndx), source.Type(), err}
+		}
+	}
+	return nil
+}
+
+// keyOf returns the key of a value for grouping and joining
+func keyOf(series Series, ndx int) string {
+	if keyer, ok := series.(RowKeyer); ok {
+		return keyer.Key(ndx)
+	}
+	return series.Format(ndx)
+}
+
+// columnSeries is the Series for the Columnable types
+type columnSeries[T Columnable] struct {
+	*Column[T]
+}
+
+func newColumnSeries[T Columnable](columnName string) (Series, error) {
+	col, err := NewColumn(columnName, []T{})
+	if err != nil {
+		return nil, err
+	}
+	return columnSeries[T]{col}, nil
+}
+
+func (s columnSeries[T]) Name() string {
+	return s.ColumnName
+}
+
+func (s columnSeries[T]) Type() reflect.Kind {
+	return s.ColumnType
+}
+
+func (s columnSeries[T]) Len() int {
+	return s.Length()
+}
+
+func (s columnSeries[T]) Parse(value string) error {
+	var val T
+	var err error
+	switch ptr := interface{}(&val).(type) {
+	case *string:
+		*ptr = value
+	case *int:
+		*ptr, err = strconv.Atoi(value)
+	case *int64:
+		*ptr, err = strconv.ParseInt(value, 10, 64)
+	case *float64:
+		*ptr, err = strconv.ParseFloat(value, 64)
+	}
+	if err != nil {
+		return err
+	}
+	s.AppendValue(val)
+	return nil
+}
+
+func (s columnSeries[T]) Format(ndx int) string {
+	switch val := interface{}(s.data[ndx]).(type) {
+	case string:
+		return val
+	case int:
+		return strconv.Itoa(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return fmt.Sprint(s.data[ndx])
+}
+
+func (s columnSeries[T]) Slice(start, stop int) (Series, error) {
+	col, err := s.Column.Slice(start, stop)
+	if err != nil {
+		return nil, err
+	}
+	return columnSeries[T]{col}, nil
+}
+
+func (s columnSeries[T]) Take(indices []int) (Series, error) {
+	col, err := s.Column.Take(indices)
+	if err != nil {
+		return nil, err
+	}
+	return columnSeries[T]{col}, nil
+}
+
+// Rename copies the values, so appending to either column never
+// changes the other
+func (s columnSeries[T]) Rename(name string) Series {
+	col := s.clone()
+	col.ColumnName = name
+	return columnSeries[T]{col}
+}
+
+func (s columnSeries[T]) CompareValues(operation FilterType, values ...interface{}) (Mask, error) {
+	converted, err := convertValues[T](s.ColumnName, values)
+	if err != nil {
+		return Mask{}, err
+	}
+	return s.Compare(operation, converted...)
+}
+
+func (s columnSeries[T]) CompareSeries(operation FilterType, other Series) (Mask, error) {
+	otherSeries, ok := other.(columnSeries[T])
+	if !ok {
+		return Mask{}, WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	return s.CompareColumn(operation, *otherSeries.Column)
+}
+
+func (s columnSeries[T]) CompareRows(i, j int) int {
+	return s.compareRows(i, j)
+}
+
+func (s columnSeries[T]) Key(ndx int) string {
+	return s.keyAt(ndx)
+}
+
+func (s columnSeries[T]) AppendSeries(other Series) error {
+	otherSeries, ok := other.(columnSeries[T])
+	if !ok {
+		return WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	s.AppendColumn(*otherSeries.Column)
+	return nil
+}
+
+func (s columnSeries[T]) display(ndx int) interface{} {
+	return s.data[ndx]
+}
+
+func (s columnSeries[T]) fillNA(value interface{}) (Series, error) {
+	converted, err := convertValues[T](s.ColumnName, []interface{}{value})
+	if err != nil {
+		return nil, err
+	}
+	return columnSeries[T]{s.FillNull(converted[0])}, nil
+}
+
+func (s columnSeries[T]) sumGroups(groups [][]int, outputName string) (Series, bool, error) {
+	if !isNumericKind(s.ColumnType) {
+		return nil, false, nil
+	}
+	return columnSeries[T]{sumGroups(*s.Column, groups, outputName)}, true, nil
+}
+
+func (s columnSeries[T]) float64Column() (*Column[float64], bool) {
+	switch col := interface{}(s.Column).(type) {
+	case *Column[int]:
+		return ConvertColumn[int, float64](*col), true
+	case *Column[int64]:
+		return ConvertColumn[int64, float64](*col), true
+	case *Column[float64]:
+		return col, true
+	}
+	return nil, false
+}
+
+func (s columnSeries[T]) interpolate() (Series, bool) {
+	switch col := interface{}(s.Column).(type) {
+	case *Column[int]:
+		return columnSeries[int]{InterpolateColumn(*col)}, true
+	case *Column[int64]:
+		return columnSeries[int64]{InterpolateColumn(*col)}, true
+	case *Column[float64]:
+		return columnSeries[float64]{InterpolateColumn(*col)}, true
+	}
+	return nil, false
+}
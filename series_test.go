@@ -0,0 +1,393 @@
+package dataframe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ipSeries is a column type outside the package, registered the way a
+// user of the package would add one
+type ipSeries struct {
+	name   string
+	values []netip.Addr
+	nulls  []bool
+}
+
+var ipKind, ipKindErr = RegisterType("ip", func(columnName string) (Series, error) {
+	return &ipSeries{name: columnName}, nil
+})
+
+func (s *ipSeries) Name() string        { return s.name }
+func (s *ipSeries) Type() reflect.Kind  { return ipKind }
+func (s *ipSeries) Len() int            { return len(s.values) }
+func (s *ipSeries) IsNull(ndx int) bool { return s.nulls[ndx] }
+func (s *ipSeries) Format(ndx int) string {
+	return s.values[ndx].String()
+}
+
+func (s *ipSeries) Parse(value string) error {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return err
+	}
+	s.values = append(s.values, addr)
+	s.nulls = append(s.nulls, false)
+	return nil
+}
+
+func (s *ipSeries) AppendNull() {
+	s.values = append(s.values, netip.Addr{})
+	s.nulls = append(s.nulls, true)
+}
+
+func (s *ipSeries) Slice(start, stop int) (Series, error) {
+	if start < 0 || stop > s.Len() || start > stop {
+		return nil, IndexOutOfBounds{s.name, stop, s.Len()}
+	}
+	indices := make([]int, 0, stop-start)
+	for ndx := start; ndx < stop; ndx++ {
+		indices = append(indices, ndx)
+	}
+	return s.Take(indices)
+}
+
+func (s *ipSeries) Take(indices []int) (Series, error) {
+	taken := &ipSeries{name: s.name}
+	for _, ndx := range indices {
+		if ndx == -1 || s.nulls[ndx] {
+			taken.AppendNull()
+			continue
+		}
+		taken.values = append(taken.values, s.values[ndx])
+		taken.nulls = append(taken.nulls, false)
+	}
+	return taken, nil
+}
+
+func (s *ipSeries) Rename(name string) Series {
+	return &ipSeries{name: name, values: s.values, nulls: s.nulls}
+}
+
+func (s *ipSeries) CompareValues(operation FilterType, values ...interface{}) (Mask, error) {
+	if operation != Equal || len(values) != 1 {
+		return Mask{}, fmt.Errorf("filter of type %s not supported", operation)
+	}
+	addr, err := netip.ParseAddr(values[0].(string))
+	if err != nil {
+		return Mask{}, err
+	}
+	mask := NewMask(s.Len())
+	for ndx, value := range s.values {
+		mask.set(ndx, !s.nulls[ndx] && value == addr)
+	}
+	return mask, nil
+}
+
+// kindSeries reports a Type other than the one ipSeries was registered
+// with, to check that AddSeries refuses it
+type kindSeries struct {
+	*ipSeries
+	kind reflect.Kind
+}
+
+func (s kindSeries) Type() reflect.Kind { return s.kind }
+
+func TestAddSeriesChecksType(t *testing.T) {
+	hosts := &ipSeries{name: "host"}
+	hosts.Parse("::1")
+	df := New()
+	if err := df.AddSeries(kindSeries{hosts, reflect.Int}); !errors.Is(err, ErrWrongColumnType) {
+		t.Errorf("expected a series claiming a built in type to be rejected, but found %v", err)
+	}
+	if err := df.AddSeries(kindSeries{hosts, reflect.Complex128}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected a series with an unregistered type to be rejected, but found %v", err)
+	}
+	if df.Length() != 0 || len(df.Names()) != 0 {
+		t.Errorf("expected rejected series to leave the dataframe empty")
+	}
+	if err := df.AddSeries(hosts); err != nil {
+		t.Errorf("expected a registered series to be added, but found %s", err)
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	if ipKindErr != nil {
+		t.Fatalf("unable to register ip type: %s", ipKindErr)
+		return
+	}
+	if kindName(ipKind) != "ip" {
+		t.Errorf("expected the registered name to be used, but found %s", kindName(ipKind))
+	}
+	for _, name := range []string{"ip", "timestamp", "string", ""} {
+		if _, err := RegisterType(name, func(columnName string) (Series, error) { return &ipSeries{name: columnName}, nil }); err == nil {
+			t.Errorf("expected registering %q to be rejected", name)
+		}
+	}
+	if _, err := RegisterType("uuid", nil); err == nil {
+		t.Errorf("expected a type without a factory to be rejected")
+	}
+	broken, err := RegisterType("broken", func(columnName string) (Series, error) {
+		return nil, fmt.Errorf("no storage for %s", columnName)
+	})
+	if err != nil {
+		t.Fatalf("unable to register broken type: %s", err)
+		return
+	}
+	schema := Schema{}
+	if err := schema.AddColumn("host", broken); err != nil {
+		t.Fatalf("unable to add registered column to schema: %s", err)
+		return
+	}
+	if _, err := schema.BuildDF(); err == nil || !strings.Contains(err.Error(), "no storage for host") {
+		t.Errorf("expected the factory error to be returned, but found %v", err)
+	}
+}
+
+func TestRegisteredTypeInDataframe(t *testing.T) {
+	content := `host,hits
+10.0.0.1,5
+::1,7
+,1
+10.0.0.1,2
+`
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "host", ColumnType: ipKind},
+		{ColumnName: "hits", ColumnType: reflect.Int},
+	})
+	if err != nil {
+		t.Fatalf("unable to create schema with a registered type: %s", err)
+		return
+	}
+	df, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true, Workers: 2, BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unable to read registered type: %s", err)
+		return
+	}
+	if columnType, _ := df.GetColumnType("host"); columnType != ipKind {
+		t.Errorf("expected the host column to be an ip column, but found %s", kindName(columnType))
+	}
+	if null, _ := df.IsNull("host", 2); !null {
+		t.Errorf("expected an empty cell to be null")
+	}
+	series, err := df.GetSeries("host")
+	if err != nil || series.Format(1) != "::1" {
+		t.Errorf("expected ::1 at index 1, but found %v", err)
+	}
+
+	_, err = ReadCSV(strings.NewReader(strings.Replace(content, "::1", "bogus", 1)), *schema, CSVOptions{HasHeader: true})
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) || parseErr.Kind != ipKind || parseErr.Row != 1 {
+		t.Errorf("expected a parse error on row 1, but found %v", err)
+	}
+
+	filtered, err := df.Filter("host", Equal, "10.0.0.1")
+	if err != nil {
+		t.Fatalf("unable to filter registered type: %s", err)
+		return
+	}
+	testIntHelper(t, "hits", 1, 2, filtered)
+	if _, err := df.Filter("host", Greater, "10.0.0.1"); err == nil {
+		t.Errorf("expected an unsupported comparison to be rejected")
+	}
+	if _, err := df.SortBy([]SortKey{{ColumnName: "host"}}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected sorting on a type without RowComparer to be rejected, but found %v", err)
+	}
+
+	grouped, err := df.GroupBy("host")
+	if err != nil {
+		t.Fatalf("unable to group on registered type: %s", err)
+		return
+	}
+	sums, err := grouped.Sum()
+	if err != nil {
+		t.Fatalf("unable to sum groups: %s", err)
+		return
+	}
+	if sums.Length() != 3 {
+		t.Errorf("expected 3 groups including nulls, but found %d", sums.Length())
+	}
+	testIntHelper(t, "hits", 0, 7, sums)
+
+	owners := New()
+	hosts := &ipSeries{name: "host"}
+	for _, value := range []string{"::1", "10.0.0.2"} {
+		hosts.Parse(value)
+	}
+	names, _ := NewColumn("owner", []string{"local", "other"})
+	if err := owners.AddSeries(hosts); err != nil {
+		t.Fatalf("unable to add series: %s", err)
+		return
+	}
+	if err := owners.AddStringColumn(*names); err != nil {
+		t.Fatalf("unable to add string column: %s", err)
+		return
+	}
+	joined, err := Join(df, owners, []string{"host"}, OuterJoin)
+	if err != nil {
+		t.Fatalf("unable to join on registered type: %s", err)
+		return
+	}
+	if joined.Length() != 5 {
+		t.Errorf("expected 5 joined rows, but found %d", joined.Length())
+	}
+	if host, _ := joined.GetSeries("host"); host.Format(4) != "10.0.0.2" {
+		t.Errorf("expected the unmatched key to be filled from the right, but found %s", host.Format(4))
+	}
+	combined, err := Concat(df, df)
+	if err != nil {
+		t.Fatalf("unable to concatenate registered type: %s", err)
+		return
+	}
+	if host, _ := combined.GetSeries("host"); combined.Length() != 8 || host.Format(5) != "::1" {
+		t.Errorf("expected the rows to be stacked, but found %d rows", combined.Length())
+	}
+
+	var buffer bytes.Buffer
+	if err := df.WriteCSVTo(&buffer, CSVWriteOptions{}); err != nil {
+		t.Fatalf("unable to write registered type: %s", err)
+		return
+	}
+	if buffer.String() != content {
+		t.Errorf("expected the registered type to round trip, but found\n%s", buffer.String())
+	}
+	if !strings.Contains(df.String(), "::1") {
+		t.Errorf("expected the table to show registered values")
+	}
+}
+
+func TestRegisteredTypeFillsAndAggregates(t *testing.T) {
+	schema, err := SchemaFromDefs([]SchemaDef{
+		{ColumnName: "desk", ColumnType: reflect.String},
+		{ColumnName: "host", ColumnType: ipKind},
+	})
+	if err != nil {
+		t.Fatalf("unable to create schema with a registered type: %s", err)
+		return
+	}
+	df, err := ReadCSV(strings.NewReader("desk,host\na,\na,10.0.0.1\nb,::1\nb,\nb,10.0.0.2\nc,\n"), *schema, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read registered type: %s", err)
+		return
+	}
+	formatAt := func(df *Dataframe, ndx int) string {
+		host, _ := df.GetSeries("host")
+		if host.IsNull(ndx) {
+			return "null"
+		}
+		return host.Format(ndx)
+	}
+	forward, err := df.FillForward("host")
+	if err != nil {
+		t.Fatalf("unable to fill registered type forward: %s", err)
+		return
+	}
+	backward, err := df.FillBackward("host")
+	if err != nil {
+		t.Fatalf("unable to fill registered type backward: %s", err)
+		return
+	}
+	filled, err := df.FillNA(map[string]interface{}{"host": "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("unable to fill registered type with a value: %s", err)
+		return
+	}
+	for _, tc := range []struct {
+		name     string
+		df       *Dataframe
+		expected []string
+	}{
+		{"forward", forward, []string{"null", "10.0.0.1", "::1", "::1", "10.0.0.2", "10.0.0.2"}},
+		{"backward", backward, []string{"10.0.0.1", "10.0.0.1", "::1", "10.0.0.2", "10.0.0.2", "null"}},
+		{"value", filled, []string{"127.0.0.1", "10.0.0.1", "::1", "127.0.0.1", "10.0.0.2", "127.0.0.1"}},
+	} {
+		for ndx, expected := range tc.expected {
+			if found := formatAt(tc.df, ndx); found != expected {
+				t.Errorf("expected %s fill to give %s at %d, but found %s", tc.name, expected, ndx, found)
+			}
+		}
+	}
+	if _, err := df.FillNA(map[string]interface{}{"host": "bogus"}); !errors.Is(err, ErrParse) {
+		t.Errorf("expected an unparsable fill value to be rejected, but found %v", err)
+	}
+
+	grouped, err := df.GroupBy("desk")
+	if err != nil {
+		t.Fatalf("unable to group: %s", err)
+		return
+	}
+	first, err := grouped.Agg(Aggregation{ColumnName: "host", Type: AggFirst, OutputName: "first"}, Aggregation{ColumnName: "host", Type: AggLast, OutputName: "last"})
+	if err != nil {
+		t.Fatalf("unable to take the first and last of a registered type: %s", err)
+		return
+	}
+	for _, tc := range []struct {
+		column   string
+		expected []string
+	}{
+		{"first", []string{"10.0.0.1", "::1", "null"}},
+		{"last", []string{"10.0.0.1", "10.0.0.2", "null"}},
+	} {
+		series, err := first.GetSeries(tc.column)
+		if err != nil || series.Type() != ipKind {
+			t.Errorf("expected %s to be an ip column, but found %v", tc.column, err)
+			continue
+		}
+		for ndx, expected := range tc.expected {
+			found := "null"
+			if !series.IsNull(ndx) {
+				found = series.Format(ndx)
+			}
+			if found != expected {
+				t.Errorf("expected %s of group %d to be %s, but found %s", tc.column, ndx, expected, found)
+			}
+		}
+	}
+	if _, err := grouped.Min("host"); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("expected the minimum of a type without RowComparer to be rejected, but found %v", err)
+	}
+}
+
+func TestRenameCopiesValues(t *testing.T) {
+	values := map[reflect.Kind][]string{
+		reflect.String:  {"a", "b"},
+		reflect.Int:     {"1", "42"},
+		reflect.Int64:   {"1", "42"},
+		reflect.Float64: {"1.5", "42"},
+		reflect.Bool:    {"true", "false"},
+		Timestamp:       {"2024-01-02T03:04:05Z", "2024-05-06T07:08:09Z"},
+		Date:            {"2024-01-02", "2024-05-06"},
+		Decimal:         {"1", "42"},
+		Categorical:     {"a", "b"},
+	}
+	for kind, texts := range values {
+		original, err := newSeries("original", kind)
+		if err != nil {
+			t.Fatalf("unable to create %s series: %s", kindName(kind), err)
+			return
+		}
+		original.Parse(texts[0])
+		original.AppendNull()
+		renamed := original.Rename("renamed")
+		// Both columns grow after the rename and must not see each other
+		if err := renamed.Parse(texts[1]); err != nil {
+			t.Fatalf("unable to parse %s: %s", texts[1], err)
+			return
+		}
+		original.Parse(texts[0])
+		renamed.AppendNull()
+		if renamed.Name() != "renamed" || original.Name() != "original" {
+			t.Errorf("expected only the %s copy to be renamed", kindName(kind))
+		}
+		if renamed.Format(2) != texts[1] || original.Format(2) != texts[0] {
+			t.Errorf("expected %s columns to keep their own values, but found %s and %s", kindName(kind), renamed.Format(2), original.Format(2))
+		}
+		if renamed.Len() != 4 || original.Len() != 3 || !renamed.IsNull(3) || original.IsNull(2) {
+			t.Errorf("expected %s columns to keep their own nulls", kindName(kind))
+		}
+	}
+}
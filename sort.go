@@ -0,0 +1,52 @@
+package dataframe
+
+import "sort"
+
+// SortKey names a column to sort by and the direction to sort it in
+type SortKey struct {
+	ColumnName string
+	Descending bool
+}
+
+// SortBy will return a pointer to a new dataframe with its rows ordered
+// by the keys provided.  Rows that tie on the first key are ordered by
+// the second key and so on.  The sort is stable, so rows that tie on
+// every key keep their original order.  Nulls are always placed last,
+// regardless of the direction.  Columns of a registered type must
+// implement RowComparer to be sorted on
+func (d Dataframe) SortBy(keys []SortKey) (*Dataframe, error) {
+	for _, key := range keys {
+		series, err := d.GetSeries(key.ColumnName)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := series.(RowComparer); !ok {
+			return nil, UnsupportedType{series.Type()}
+		}
+	}
+	indices := make([]int, d.numberRows)
+	for ndx := range indices {
+		indices[ndx] = ndx
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		for _, key := range keys {
+			result := d.compareRows(key.ColumnName, indices[a], indices[b])
+			if result == 0 {
+				continue
+			}
+			if key.Descending && !d.isNullAt(key.ColumnName, indices[a]) && !d.isNullAt(key.ColumnName, indices[b]) {
+				result = -result
+			}
+			return result < 0
+		}
+		return false
+	})
+	return d.Take(indices)
+}
+
+func (d Dataframe) compareRows(columnName string, i, j int) int {
+	if comparer, ok := d.series(columnName).(RowComparer); ok {
+		return comparer.CompareRows(i, j)
+	}
+	return 0
+}
@@ -0,0 +1,48 @@
+package dataframe
+
+import (
+	"testing"
+)
+
+func TestSortBy(t *testing.T) {
+	df := createTestDataframe(t)
+	sorted, err := df.SortBy([]SortKey{{ColumnName: "Close"}, {ColumnName: "Volume", Descending: true}})
+	if err != nil {
+		t.Fatalf("unable to sort dataframe: %s", err)
+		return
+	}
+	if sorted.Length() != df.Length() {
+		t.Errorf("expected %d rows after sorting, but found %d", df.Length(), sorted.Length())
+	}
+	expectedCloses := []float64{17.58, 17.65, 17.675, 17.68, 17.72, 17.74, 17.74}
+	for ndx, expected := range expectedCloses {
+		testFloatHelper(t, "Close", ndx, expected, sorted)
+	}
+	// The two rows closing at 17.74 are ordered by descending volume
+	testIntHelper(t, "Volume", 5, 160450, sorted)
+	testIntHelper(t, "Volume", 6, 132764, sorted)
+	// Every other column must be permuted with the sort key
+	testFloatHelper(t, "Open", 0, 17.65, sorted)
+	testBigIntHelper(t, "WindowStart", 0, 16384212000, sorted)
+	if _, err := df.SortBy([]SortKey{{ColumnName: "Missing"}}); err == nil {
+		t.Errorf("expected an error when sorting by a missing column")
+	}
+}
+
+func TestSortByIsStableWithNullsLast(t *testing.T) {
+	df := buildTestDataframe(t, missingTestColumns...)
+	for _, descending := range []bool{false, true} {
+		sorted, err := df.SortBy([]SortKey{{ColumnName: "Price", Descending: descending}})
+		if err != nil {
+			t.Fatalf("unable to sort dataframe: %s", err)
+			return
+		}
+		for ndx := 2; ndx < 5; ndx++ {
+			if isNull, _ := sorted.IsNull("Price", ndx); !isNull {
+				t.Errorf("expected nulls to sort last when descending is %t, but index %d is not null", descending, ndx)
+			}
+		}
+		// The null prices keep their original relative order
+		testIntHelper(t, "Volume", 2, 10, sorted)
+	}
+}
@@ -0,0 +1,342 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// EpochUnit is the unit of an integer counted from the Unix epoch
+type EpochUnit string
+
+const (
+	EpochSeconds      EpochUnit = "s"
+	EpochMilliseconds EpochUnit = "ms"
+	EpochMicroseconds EpochUnit = "us"
+	EpochNanoseconds  EpochUnit = "ns"
+)
+
+func (e EpochUnit) nanoseconds() (int64, bool) {
+	switch e {
+	case EpochSeconds:
+		return int64(time.Second), true
+	case EpochMilliseconds:
+		return int64(time.Millisecond), true
+	case EpochMicroseconds:
+		return int64(time.Microsecond), true
+	case EpochNanoseconds:
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
+// TimeFormat controls how a time column is parsed from and written to
+// text
+type TimeFormat struct {
+	// Layout is a Go time layout such as time.RFC3339 or
+	// "2006-01-02 15:04:05".  It defaults to time.RFC3339Nano for
+	// timestamps and time.DateOnly for dates
+	Layout string
+	// Epoch reads and writes values as integers counted from the Unix
+	// epoch in this unit instead of using Layout
+	Epoch EpochUnit
+	// Location is used for layouts without a time zone and when
+	// rendering values.  It defaults to UTC
+	Location *time.Location
+}
+
+// TimeColumn holds timestamps or dates as nanoseconds since the Unix
+// epoch, so the int64 methods of the embedded Column work on the raw
+// values.  Dates are stored as midnight UTC, and every value must fall
+// between the years 1678 and 2262
+type TimeColumn struct {
+	Column[int64]
+	Format TimeFormat
+}
+
+func (c TimeColumn) location() *time.Location {
+	if c.Format.Location == nil {
+		return time.UTC
+	}
+	return c.Format.Location
+}
+
+func (c TimeColumn) layout() string {
+	switch {
+	case c.Format.Layout != "":
+		return c.Format.Layout
+	case c.ColumnType == Date:
+		return time.DateOnly
+	default:
+		return time.RFC3339Nano
+	}
+}
+
+// toNanos converts a time to the value stored for it in this column
+func (c TimeColumn) toNanos(t time.Time) int64 {
+	if c.ColumnType == Date {
+		t = t.In(c.location())
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return t.UnixNano()
+}
+
+func (c TimeColumn) toTime(nanos int64) time.Time {
+	if c.ColumnType == Date {
+		return time.Unix(0, nanos).UTC()
+	}
+	return time.Unix(0, nanos).In(c.location())
+}
+
+// GetTimeAtIndex will fetch the value at the ndx provided as a time.Time
+// in the column's location.  If the index is out of bounds, it will
+// return an IndexOutOfBounds error
+func (c TimeColumn) GetTimeAtIndex(ndx int) (time.Time, error) {
+	nanos, err := c.GetValueAtIndex(ndx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.toTime(nanos), nil
+}
+
+// AppendTime will append the time provided to the column
+func (c *TimeColumn) AppendTime(t time.Time) {
+	c.AppendValue(c.toNanos(t))
+}
+
+// parse converts text to the value stored for it using the column's
+// TimeFormat
+func (c TimeColumn) parse(value string) (int64, error) {
+	if unit, ok := c.Format.Epoch.nanoseconds(); ok {
+		epoch, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if epoch > math.MaxInt64/unit || epoch < math.MinInt64/unit {
+			return 0, &strconv.NumError{Func: "ParseInt", Num: value, Err: strconv.ErrRange}
+		}
+		return c.toNanos(time.Unix(0, epoch*unit)), nil
+	}
+	t, err := time.ParseInLocation(c.layout(), value, c.location())
+	if err != nil {
+		return 0, err
+	}
+	return c.toNanos(t), nil
+}
+
+// format renders the value at ndx as text using the column's TimeFormat
+func (c TimeColumn) format(ndx int) string {
+	nanos := c.data[ndx]
+	if unit, ok := c.Format.Epoch.nanoseconds(); ok {
+		return strconv.FormatInt(nanos/unit, 10)
+	}
+	return c.toTime(nanos).Format(c.layout())
+}
+
+// display renders the value at ndx for a table, using the layout even
+// when the column is written as epoch integers
+func (c TimeColumn) display(ndx int) string {
+	return c.toTime(c.data[ndx]).Format(c.layout())
+}
+
+// convertTimes converts values used against the column, which can be
+// time.Time values or text in the column's format, to stored values
+func (c TimeColumn) convertTimes(values []interface{}) ([]int64, error) {
+	converted := make([]int64, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case time.Time:
+			converted = append(converted, c.toNanos(v))
+		case string:
+			nanos, err := c.parse(v)
+			if err != nil {
+				return nil, ParseError{-1, c.ColumnName, v, c.ColumnType, err}
+			}
+			converted = append(converted, nanos)
+		default:
+			return nil, fmt.Errorf("value %v of type %T cannot be used with %s column %s: %w", value, value, kindName(c.ColumnType), c.ColumnName, ErrWrongColumnType)
+		}
+	}
+	return converted, nil
+}
+
+// wrap turns a column of raw values derived from this one back into a
+// time column with the same type and format
+func (c TimeColumn) wrap(col *Column[int64]) *TimeColumn {
+	col.ColumnType = c.ColumnType
+	return &TimeColumn{Column: *col, Format: c.Format}
+}
+
+// Slice takes a start and stop parameter and returns a new column of
+// the same name, type and format
+func (c TimeColumn) Slice(start, stop int) (*TimeColumn, error) {
+	col, err := c.Column.Slice(start, stop)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrap(col), nil
+}
+
+// Take returns a new column of the same name, type and format holding
+// the values at the provided indices.  An index of -1 produces a null
+func (c TimeColumn) Take(indices []int) (*TimeColumn, error) {
+	col, err := c.Column.Take(indices)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrap(col), nil
+}
+
+// FillNull returns a new column where every null has been replaced
+// with the time provided
+func (c TimeColumn) FillNull(value time.Time) *TimeColumn {
+	return c.wrap(c.Column.FillNull(c.toNanos(value)))
+}
+
+// FillForward returns a new column where every null has been replaced
+// with the last value before it that was not null
+func (c TimeColumn) FillForward() *TimeColumn {
+	return c.wrap(c.Column.FillForward())
+}
+
+// FillBackward returns a new column where every null has been replaced
+// with the next value after it that is not null
+func (c TimeColumn) FillBackward() *TimeColumn {
+	return c.wrap(c.Column.FillBackward())
+}
+
+// Coalesce returns a new column where every null has been replaced with
+// the value at the same index in the other column
+func (c TimeColumn) Coalesce(other TimeColumn) (*TimeColumn, error) {
+	col, err := c.Column.Coalesce(other.Column)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrap(col), nil
+}
+
+// NewTimeColumn will create a new Timestamp or Date column from existing
+// times.  Use []time.Time{} as the data argument to create an empty
+// column
+func NewTimeColumn(colName string, kind reflect.Kind, format TimeFormat, data []time.Time) (*TimeColumn, error) {
+	if !isTimeKind(kind) {
+		return nil, UnsupportedType{kind}
+	}
+	col := &TimeColumn{
+		Column: Column[int64]{ColumnName: colName, ColumnType: kind},
+		Format: format,
+	}
+	col.data = make([]int64, 0, len(data))
+	for _, t := range data {
+		col.AppendTime(t)
+	}
+	return col, nil
+}
+
+// timeSeries is the Series for TimeColumn
+type timeSeries struct {
+	*TimeColumn
+}
+
+func newTimeSeries(columnName string, kind reflect.Kind) (Series, error) {
+	col, err := NewTimeColumn(columnName, kind, TimeFormat{}, []time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return timeSeries{col}, nil
+}
+
+func (s timeSeries) Name() string {
+	return s.ColumnName
+}
+
+func (s timeSeries) Type() reflect.Kind {
+	return s.ColumnType
+}
+
+func (s timeSeries) Len() int {
+	return s.Length()
+}
+
+func (s timeSeries) Parse(value string) error {
+	val, err := s.parse(value)
+	if err != nil {
+		return err
+	}
+	s.AppendValue(val)
+	return nil
+}
+
+func (s timeSeries) Format(ndx int) string {
+	return s.format(ndx)
+}
+
+func (s timeSeries) Slice(start, stop int) (Series, error) {
+	col, err := s.TimeColumn.Slice(start, stop)
+	if err != nil {
+		return nil, err
+	}
+	return timeSeries{col}, nil
+}
+
+func (s timeSeries) Take(indices []int) (Series, error) {
+	col, err := s.TimeColumn.Take(indices)
+	if err != nil {
+		return nil, err
+	}
+	return timeSeries{col}, nil
+}
+
+func (s timeSeries) Rename(name string) Series {
+	col := s.wrap(s.clone())
+	col.ColumnName = name
+	return timeSeries{col}
+}
+
+func (s timeSeries) CompareValues(operation FilterType, values ...interface{}) (Mask, error) {
+	converted, err := s.convertTimes(values)
+	if err != nil {
+		return Mask{}, err
+	}
+	return s.Compare(operation, converted...)
+}
+
+func (s timeSeries) CompareSeries(operation FilterType, other Series) (Mask, error) {
+	otherSeries, ok := other.(timeSeries)
+	if !ok || otherSeries.ColumnType != s.ColumnType {
+		return Mask{}, WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	return s.CompareColumn(operation, otherSeries.Column)
+}
+
+func (s timeSeries) CompareRows(i, j int) int {
+	return s.compareRows(i, j)
+}
+
+func (s timeSeries) Key(ndx int) string {
+	return s.keyAt(ndx)
+}
+
+func (s timeSeries) AppendSeries(other Series) error {
+	otherSeries, ok := other.(timeSeries)
+	if !ok || otherSeries.ColumnType != s.ColumnType {
+		return WrongColumnTypeError{other.Name(), s.ColumnType, other.Type()}
+	}
+	s.AppendColumn(otherSeries.Column)
+	return nil
+}
+
+func (s timeSeries) fillNA(value interface{}) (Series, error) {
+	converted, err := s.convertTimes([]interface{}{value})
+	if err != nil {
+		return nil, err
+	}
+	return timeSeries{s.wrap(s.Column.FillNull(converted[0]))}, nil
+}
+
+func (s timeSeries) display(ndx int) interface{} {
+	return s.TimeColumn.display(ndx)
+}
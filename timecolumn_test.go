@@ -0,0 +1,133 @@
+package dataframe
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTimeSchema(t *testing.T) *Schema {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database is not available: %s", err)
+		return nil
+	}
+	schema := &Schema{}
+	if err := schema.AddColumn("ticker", reflect.String); err != nil {
+		t.Fatalf("unable to add column: %s", err)
+	}
+	if err := schema.AddTimeColumn("window_start", Timestamp, TimeFormat{Epoch: EpochSeconds, Location: newYork}); err != nil {
+		t.Fatalf("unable to add epoch column: %s", err)
+	}
+	if err := schema.AddColumn("trade_date", Date); err != nil {
+		t.Fatalf("unable to add date column: %s", err)
+	}
+	if err := schema.AddTimeColumn("updated", Timestamp, TimeFormat{Layout: time.DateTime, Location: newYork}); err != nil {
+		t.Fatalf("unable to add layout column: %s", err)
+	}
+	return schema
+}
+
+func TestTimeColumns(t *testing.T) {
+	content := `ticker,window_start,trade_date,updated
+AAA,1638334800,2021-12-01,2021-12-01 09:30:00
+BBB,1638421200,2021-12-02,
+CCC,1638507600,2021-12-03,2021-12-03 16:00:00
+`
+	schema := createTimeSchema(t)
+	df, err := ReadCSV(strings.NewReader(content), *schema, CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to read time columns: %s", err)
+		return
+	}
+	start, err := df.GetTimeValue("window_start", 0)
+	if err != nil {
+		t.Fatalf("unable to get timestamp: %s", err)
+		return
+	}
+	if !start.Equal(time.Unix(1638334800, 0)) || start.Location().String() != "America/New_York" {
+		t.Errorf("unexpected timestamp %s", start)
+	}
+	date, err := df.GetTimeValue("trade_date", 1)
+	if err != nil || !date.Equal(time.Date(2021, 12, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %s (%v)", date, err)
+	}
+	updated, err := df.GetTimeValue("updated", 2)
+	if err != nil || updated.UTC().Hour() != 21 {
+		t.Errorf("expected 16:00 in New York to be 21:00 UTC, but found %s (%v)", updated, err)
+	}
+	if null, _ := df.IsNull("updated", 1); !null {
+		t.Errorf("expected an empty timestamp to be null")
+	}
+	if _, err := df.GetTimeValue("ticker", 0); !errors.Is(err, ErrWrongColumnType) {
+		t.Errorf("expected a wrong column type error, but found %v", err)
+	}
+
+	later, err := df.Filter("trade_date", GreaterEq, "2021-12-02")
+	if err != nil {
+		t.Fatalf("unable to filter dates with text: %s", err)
+		return
+	}
+	if later.Length() != 2 {
+		t.Errorf("expected 2 rows from December 2nd on, but found %d", later.Length())
+	}
+	earlier, err := df.Filter("window_start", Lesser, time.Unix(1638421200, 0))
+	if err != nil {
+		t.Fatalf("unable to filter timestamps with a time: %s", err)
+		return
+	}
+	testStringHelper(t, "ticker", 0, "AAA", earlier)
+	sorted, err := df.SortBy([]SortKey{{ColumnName: "trade_date", Descending: true}})
+	if err != nil {
+		t.Fatalf("unable to sort dates: %s", err)
+		return
+	}
+	testStringHelper(t, "ticker", 0, "CCC", sorted)
+
+	var buffer bytes.Buffer
+	if err := df.WriteCSVTo(&buffer, CSVWriteOptions{}); err != nil {
+		t.Fatalf("unable to write time columns: %s", err)
+		return
+	}
+	if buffer.String() != content {
+		t.Errorf("expected the time columns to be written in their formats, but found\n%s", buffer.String())
+	}
+	table, err := df.Table(0, 0)
+	if err != nil {
+		t.Fatalf("unable to render table: %s", err)
+		return
+	}
+	if !strings.Contains(table.Render(), "2021-12-01T00:00:00-05:00") {
+		t.Errorf("expected epoch timestamps to be rendered as times in the table")
+	}
+
+	_, err = ReadCSV(strings.NewReader(strings.Replace(content, "2021-12-03,", "12/03/2021,", 1)), *schema, CSVOptions{HasHeader: true})
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) || parseErr.Kind != Date || !strings.Contains(err.Error(), "into date") {
+		t.Errorf("expected a parse error for a date column, but found %v", err)
+	}
+}
+
+func TestInferTimeColumns(t *testing.T) {
+	content := `day,at
+2024-03-01,2024-03-01T14:30:00Z
+2024-03-04,2024-03-04T14:30:00.5-05:00
+`
+	df, err := ReadCSVInfer(strings.NewReader(content), CSVOptions{HasHeader: true})
+	if err != nil {
+		t.Fatalf("unable to infer time columns: %s", err)
+		return
+	}
+	for columnName, expected := range map[string]reflect.Kind{"day": Date, "at": Timestamp} {
+		if columnType, _ := df.GetColumnType(columnName); columnType != expected {
+			t.Errorf("expected column %s to be inferred as %s, but found %s", columnName, kindName(expected), kindName(columnType))
+		}
+	}
+	at, err := df.GetTimeValue("at", 1)
+	if err != nil || !at.Equal(time.Date(2024, 3, 4, 19, 30, 0, 500000000, time.UTC)) {
+		t.Errorf("unexpected timestamp %s (%v)", at, err)
+	}
+}
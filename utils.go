@@ -1,6 +1,11 @@
 package dataframe
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+)
 
 func FilterArray(ndx int, operation FilterType, items []interface{}) ([]interface{}, error) {
 	switch operation {
@@ -21,25 +26,112 @@ func FilterArray(ndx int, operation FilterType, items []interface{}) ([]interfac
 	}
 }
 
-func getBoundsForFilter(operation FilterType, ndx, length int) (int, int) {
+func getMin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// newComparison builds a function that reports whether a value
+// satisfies a two sided operation against another value
+func newComparison[T Columnable](operation FilterType) (func(a, b T) bool, error) {
 	switch operation {
+	case Equal:
+		return func(a, b T) bool { return a == b }, nil
+	case NotEqual:
+		return func(a, b T) bool { return a != b }, nil
 	case Greater:
-		return ndx + 1, length - 1
+		return func(a, b T) bool { return a > b }, nil
 	case GreaterEq:
-		return ndx, length - 1
+		return func(a, b T) bool { return a >= b }, nil
 	case Lesser:
-		return 0, ndx - 1
+		return func(a, b T) bool { return a < b }, nil
 	case LesserEq:
-		return 0, ndx
-	case Equal:
-		return ndx - 1, ndx
+		return func(a, b T) bool { return a <= b }, nil
+	default:
+		return nil, fmt.Errorf("filter of type %s cannot compare two values", operation)
 	}
-	return -1, -1
 }
 
-func getMin(a, b int) int {
-	if a < b {
-		return a
+// newPredicate builds a function that reports whether a single value
+// satisfies the operation against the provided values
+func newPredicate[T Columnable](operation FilterType, values []T) (func(T) bool, error) {
+	switch operation {
+	case In:
+		if len(values) == 0 {
+			return nil, fmt.Errorf("filter of type %s requires at least 1 value", operation)
+		}
+		return func(v T) bool { return slices.Contains(values, v) }, nil
+	case Between:
+		if len(values) != 2 {
+			return nil, fmt.Errorf("filter of type %s requires 2 values, but found %d", operation, len(values))
+		}
+		return func(v T) bool { return v >= values[0] && v <= values[1] }, nil
+	}
+	comparison, err := newComparison[T](operation)
+	if err != nil {
+		return nil, fmt.Errorf("filter of type %s not supported", operation)
+	}
+	if len(values) != 1 {
+		return nil, fmt.Errorf("filter of type %s requires 1 value, but found %d", operation, len(values))
+	}
+	return func(v T) bool { return comparison(v, values[0]) }, nil
+}
+
+// convertValues converts the loosely typed values given to a dataframe
+// method into the type of the column they are used with.  Numeric
+// values may be given for any numeric column as long as the conversion
+// does not lose information
+func convertValues[T Columnable](columnName string, values []interface{}) ([]T, error) {
+	converted := make([]T, 0, len(values))
+	target := reflect.TypeOf(*new(T))
+	for _, value := range values {
+		if v, ok := value.(T); ok {
+			converted = append(converted, v)
+			continue
+		}
+		rv := reflect.ValueOf(value)
+		if !isNumericKind(target.Kind()) || !rv.IsValid() || !(rv.CanInt() || rv.CanUint() || rv.CanFloat()) {
+			return nil, fmt.Errorf("value %v of type %T cannot be used with %s column %s: %w", value, value, target.Kind(), columnName, ErrWrongColumnType)
+		}
+		// NaN never equals itself, so it is checked here rather than by
+		// the round trip below
+		if rv.CanFloat() && math.IsNaN(rv.Float()) {
+			if target.Kind() != reflect.Float64 {
+				return nil, fmt.Errorf("NaN cannot be used with %s column %s: %w", target.Kind(), columnName, ErrWrongColumnType)
+			}
+			converted = append(converted, rv.Convert(target).Interface().(T))
+			continue
+		}
+		cv := rv.Convert(target)
+		if cv.Convert(rv.Type()).Interface() != value {
+			return nil, fmt.Errorf("value %v cannot be converted to %s for column %s without losing precision", value, target.Kind(), columnName)
+		}
+		converted = append(converted, cv.Interface().(T))
+	}
+	return converted, nil
+}
+
+// convertBools checks that every value used against a bool column is a
+// bool
+func convertBools(columnName string, values []interface{}) ([]bool, error) {
+	converted := make([]bool, 0, len(values))
+	for _, value := range values {
+		v, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("value %v of type %T cannot be used with bool column %s: %w", value, value, columnName, ErrWrongColumnType)
+		}
+		converted = append(converted, v)
+	}
+	return converted, nil
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int64, reflect.Float64, Decimal:
+		return true
+	default:
+		return false
 	}
-	return b
 }
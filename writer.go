@@ -0,0 +1,223 @@
+package dataframe
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// QuotePolicy controls which fields are wrapped in quotes when writing
+// CSV
+type QuotePolicy string
+
+const (
+	// QuoteMinimal only quotes fields that contain the delimiter, a quote
+	// or a line break
+	QuoteMinimal QuotePolicy = "Minimal"
+	// QuoteAll quotes every field
+	QuoteAll QuotePolicy = "All"
+	// QuoteNonNumeric quotes every field that is not from a numeric column.
+	// Nulls are never quoted so that they read back as null
+	QuoteNonNumeric QuotePolicy = "NonNumeric"
+)
+
+// CSVWriteOptions controls how a Dataframe is written as CSV
+type CSVWriteOptions struct {
+	// Delimiter separates the fields of a row.  It defaults to a comma
+	Delimiter rune
+	// OmitHeader skips writing the column names as the first row
+	OmitHeader bool
+	// FloatFormat is the strconv format used for float64 columns, such as
+	// 'f', 'e' or 'g'.  When zero, floats are written in the shortest
+	// decimal form that reads back to the same value
+	FloatFormat byte
+	// FloatPrecision is the number of digits passed to strconv with
+	// FloatFormat.  Nil gives the shortest representation, the same as -1
+	FloatPrecision *int
+	// NullValue is written in place of nulls.  It defaults to an empty
+	// field
+	NullValue string
+	// Quote decides which fields are quoted, defaulting to QuoteMinimal
+	Quote QuotePolicy
+	// UseCRLF ends each row with \r\n instead of \n
+	UseCRLF bool
+	// Compression compresses the output.  When writing to a file and this
+	// is NoCompression, it is picked from the file extension instead.  Use
+	// Uncompressed to write a plain file whatever its extension
+	Compression Compression
+}
+
+// CSVWriter streams dataframes to an io.Writer as CSV.  The header is
+// written before the first dataframe, so a series of dataframes with the
+// same columns, such as the chunks of a CSVChunkReader, can be written
+// as one file
+type CSVWriter struct {
+	opts        CSVWriteOptions
+	csvWriter   *csv.Writer
+	buffer      *bufio.Writer
+	wroteHeader bool
+}
+
+// NewCSVWriter creates a CSVWriter that writes to w.  Flush must be called
+// once every dataframe has been written
+func NewCSVWriter(w io.Writer, opts CSVWriteOptions) *CSVWriter {
+	if opts.Delimiter == 0 {
+		opts.Delimiter = ','
+	}
+	if opts.Quote == "" {
+		opts.Quote = QuoteMinimal
+	}
+	c := &CSVWriter{opts: opts}
+	if opts.Quote == QuoteMinimal {
+		c.csvWriter = csv.NewWriter(w)
+		c.csvWriter.Comma = opts.Delimiter
+		c.csvWriter.UseCRLF = opts.UseCRLF
+	} else {
+		c.buffer = bufio.NewWriter(w)
+	}
+	return c
+}
+
+// Write writes every row of the dataframe, preceded by the header if
+// this is the first dataframe written and OmitHeader is not set
+func (c *CSVWriter) Write(df *Dataframe) error {
+	switch c.opts.Quote {
+	case QuoteMinimal, QuoteAll, QuoteNonNumeric:
+	default:
+		return fmt.Errorf("quote policy %s not supported", c.opts.Quote)
+	}
+	numeric := make([]bool, len(df.columns))
+	for ndx, series := range df.columns {
+		numeric[ndx] = isNumericKind(series.Type())
+	}
+	if !c.wroteHeader && !c.opts.OmitHeader {
+		err := c.writeRecord(df.Names(), make([]bool, len(df.columns)), nil)
+		if err != nil {
+			return fmt.Errorf("unable to write header: %w", err)
+		}
+	}
+	c.wroteHeader = true
+	record := make([]string, len(df.columns))
+	nulls := make([]bool, len(df.columns))
+	for ndx := 0; ndx < df.numberRows; ndx++ {
+		for columnNdx, series := range df.columns {
+			nulls[columnNdx] = series.IsNull(ndx)
+			record[columnNdx] = c.formatCell(series, ndx, nulls[columnNdx])
+		}
+		err := c.writeRecord(record, numeric, nulls)
+		if err != nil {
+			return fmt.Errorf("unable to write row %d: %w", ndx, err)
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered rows to the underlying io.Writer
+func (c *CSVWriter) Flush() error {
+	if c.csvWriter != nil {
+		c.csvWriter.Flush()
+		return c.csvWriter.Error()
+	}
+	return c.buffer.Flush()
+}
+
+func (c *CSVWriter) formatCell(series Series, ndx int, isNull bool) string {
+	if isNull {
+		return c.opts.NullValue
+	}
+	if floats, ok := series.(columnSeries[float64]); ok && c.opts.FloatFormat != 0 {
+		precision := -1
+		if c.opts.FloatPrecision != nil {
+			precision = *c.opts.FloatPrecision
+		}
+		return strconv.FormatFloat(floats.data[ndx], c.opts.FloatFormat, precision, 64)
+	}
+	return series.Format(ndx)
+}
+
+func (c *CSVWriter) writeRecord(record []string, numeric, nulls []bool) error {
+	if c.csvWriter != nil {
+		return c.csvWriter.Write(record)
+	}
+	for ndx, field := range record {
+		if ndx > 0 {
+			if _, err := c.buffer.WriteRune(c.opts.Delimiter); err != nil {
+				return err
+			}
+		}
+		isNull := nulls != nil && nulls[ndx]
+		quote := !isNull && (c.opts.Quote == QuoteAll || !numeric[ndx]) || c.fieldNeedsQuotes(field)
+		if quote {
+			field = `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+		}
+		if _, err := c.buffer.WriteString(field); err != nil {
+			return err
+		}
+	}
+	terminator := "\n"
+	if c.opts.UseCRLF {
+		terminator = "\r\n"
+	}
+	_, err := c.buffer.WriteString(terminator)
+	return err
+}
+
+// fieldNeedsQuotes reports whether a field must be quoted to be read back
+// correctly, matching the rules used by encoding/csv
+func (c *CSVWriter) fieldNeedsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, c.opts.Delimiter) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	return field[0] == ' ' || field[0] == '\t'
+}
+
+// WriteCSV is a function that takes a filename and returns an error
+// if the file cannot be written.
+func (d Dataframe) WriteCSV(filename string) error {
+	return d.WriteCSVWithOptions(filename, CSVWriteOptions{})
+}
+
+// WriteCSVWithOptions writes the dataframe to the named file using the
+// provided options to control the format
+func (d Dataframe) WriteCSVWithOptions(filename string, opts CSVWriteOptions) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("could not open file %s for writing: %w", filename, err)
+	}
+	if opts.Compression == NoCompression {
+		opts.Compression = CompressionFromFilename(filename)
+	}
+	err = d.WriteCSVTo(f, opts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not write to file %s: %w", filename, err)
+	}
+	return nil
+}
+
+// WriteCSVTo writes the dataframe as CSV to w, such as an HTTP response
+// or a bytes.Buffer, compressing it if opts.Compression is set
+func (d Dataframe) WriteCSVTo(w io.Writer, opts CSVWriteOptions) error {
+	compressed, err := NewCompressWriter(w, opts.Compression)
+	if err != nil {
+		return err
+	}
+	writer := NewCSVWriter(compressed, opts)
+	err = writer.Write(&d)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := compressed.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
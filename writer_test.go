@@ -0,0 +1,82 @@
+package dataframe
+
+import (
+	"bytes"
+	"testing"
+)
+
+var writerTestColumns = []testColumn{
+	{"Symbol", []string{"DF,RAME", "", "A\"B"}, []bool{true, false, true}},
+	{"Volume", []int{171463, 0, 3}, []bool{true, false, true}},
+	{"Close", []float64{17.675, 16383348000, 0.5}, nil},
+}
+
+func TestCSVWriterOptions(t *testing.T) {
+	df := buildTestDataframe(t, writerTestColumns...)
+	precision := 2
+	testCases := []struct {
+		name     string
+		opts     CSVWriteOptions
+		expected string
+	}{
+		{
+			"defaults",
+			CSVWriteOptions{},
+			"Symbol,Volume,Close\n\"DF,RAME\",171463,17.675\n,,16383348000\n\"A\"\"B\",3,0.5\n",
+		},
+		{
+			"formatted",
+			CSVWriteOptions{Delimiter: '|', OmitHeader: true, FloatFormat: 'f', FloatPrecision: &precision, NullValue: "NA", UseCRLF: true},
+			"DF,RAME|171463|17.68\r\nNA|NA|16383348000.00\r\n\"A\"\"B\"|3|0.50\r\n",
+		},
+		{
+			"format without precision",
+			CSVWriteOptions{OmitHeader: true, FloatFormat: 'f'},
+			"\"DF,RAME\",171463,17.675\n,,16383348000\n\"A\"\"B\",3,0.5\n",
+		},
+		{
+			"quote all",
+			CSVWriteOptions{Quote: QuoteAll, OmitHeader: true},
+			"\"DF,RAME\",\"171463\",\"17.675\"\n,,\"16383348000\"\n\"A\"\"B\",\"3\",\"0.5\"\n",
+		},
+		{
+			"quote non numeric",
+			CSVWriteOptions{Quote: QuoteNonNumeric},
+			"\"Symbol\",\"Volume\",\"Close\"\n\"DF,RAME\",171463,17.675\n,,16383348000\n\"A\"\"B\",3,0.5\n",
+		},
+	}
+	for _, tc := range testCases {
+		var buffer bytes.Buffer
+		w := NewCSVWriter(&buffer, tc.opts)
+		if err := w.Write(df); err != nil {
+			t.Errorf("unable to write %s csv: %s", tc.name, err)
+			continue
+		}
+		if err := w.Flush(); err != nil {
+			t.Errorf("unable to flush %s csv: %s", tc.name, err)
+			continue
+		}
+		if buffer.String() != tc.expected {
+			t.Errorf("expected %s csv to be %q, but found %q", tc.name, tc.expected, buffer.String())
+		}
+	}
+}
+
+func TestCSVWriterWritesHeaderOnce(t *testing.T) {
+	df := buildTestDataframe(t, writerTestColumns...)
+	var buffer bytes.Buffer
+	w := NewCSVWriter(&buffer, CSVWriteOptions{})
+	for i := 0; i < 2; i++ {
+		if err := w.Write(df); err != nil {
+			t.Fatalf("unable to write csv: %s", err)
+			return
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("unable to flush csv: %s", err)
+		return
+	}
+	if count := bytes.Count(buffer.Bytes(), []byte("Symbol")); count != 1 {
+		t.Errorf("expected the header to be written once, but found it %d times", count)
+	}
+}